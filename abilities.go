@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Ability struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Generation    NamedResource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Pokemon []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+		Pokemon  NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+func commandAbility(config *Config) error {
+	args, flags, err := parseFlags(config.Args, "caught")
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which ability? Usage: ability <name> [--caught]")
+		return nil
+	}
+	var ability Ability
+	err = getJSON(pokeAPI+"ability/"+args[0], &ability)
+	if isNotFound(err) {
+		fmt.Println(args[0], "is not an ability")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (%s)\n", ability.Name, ability.Generation.Name)
+	for _, entry := range ability.EffectEntries {
+		if entry.Language.Name == defaultLang {
+			fmt.Println(strings.Join(strings.Fields(entry.ShortEffect), " "))
+			fmt.Println(strings.Join(strings.Fields(entry.Effect), " "))
+		}
+	}
+
+	holders, caughtN := abilityHolders(ability, func(name string) bool {
+		_, ok := pokedex.caughtSpecies[name]
+		return ok
+	}, flags["caught"] != "")
+	fmt.Printf("Pokemon with %s (%d caught of %d):\n", ability.Name, caughtN, len(ability.Pokemon))
+	for _, holder := range holders {
+		fmt.Println("   -", holder)
+	}
+	return nil
+}
+
+// abilityHolders lists the Pokemon that can have ability, marking hidden
+// abilities and the ones already caught, and counts the caught ones.
+func abilityHolders(ability Ability, caught func(name string) bool, onlyCaught bool) ([]string, int) {
+	var holders []string
+	caughtN := 0
+	for _, p := range ability.Pokemon {
+		label := p.Pokemon.Name
+		if p.IsHidden {
+			label += " (hidden)"
+		}
+		if caught(p.Pokemon.Name) {
+			label += " [caught]"
+			caughtN++
+		} else if onlyCaught {
+			continue
+		}
+		holders = append(holders, label)
+	}
+	return holders, caughtN
+}
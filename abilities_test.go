@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAbilityHolders(t *testing.T) {
+	const fixture = `{"name": "static", "pokemon": [
+		{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}},
+		{"is_hidden": false, "slot": 1, "pokemon": {"name": "raichu"}},
+		{"is_hidden": true, "slot": 3, "pokemon": {"name": "electrode"}}
+	]}`
+	var ability Ability
+	err := json.Unmarshal([]byte(fixture), &ability)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caught := func(name string) bool { return name == "pikachu" || name == "electrode" }
+
+	holders, caughtN := abilityHolders(ability, caught, false)
+	if caughtN != 2 || strings.Join(holders, ",") != "pikachu [caught],raichu,electrode (hidden) [caught]" {
+		t.Errorf("unexpected holders %v (%d caught)", holders, caughtN)
+	}
+	holders, _ = abilityHolders(ability, caught, true)
+	if strings.Join(holders, ",") != "pikachu [caught],electrode (hidden) [caught]" {
+		t.Errorf("unexpected caught holders %v", holders)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NamedResource is the {name, url} pair PokeAPI uses to link resources.
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// streamDecoder is implemented by views that walk the token stream themselves,
+// decoding large arrays one element at a time instead of building the whole
+// array as an intermediate value. It saves decoding work and allocations, not
+// the raw response, which getJSON keeps whole for the cache.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+// resourceID returns the id at the end of a PokeAPI url such as
+// https://pokeapi.co/api/v2/pokemon/25/, or 0 if there isn't one.
+func resourceID(url string) int {
+	url = strings.TrimSuffix(url, "/")
+	id, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+type statusError struct {
+	url  string
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: status code %d", e.url, e.code)
+}
+
+func isNotFound(err error) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound
+}
+
+// getJSON decodes the resource at url into v. Fresh responses are decoded
+// straight off the response body, so only the fields v declares are ever
+// materialized. The raw bytes are teed into pokeCache for next time, so the
+// whole payload is still held in memory once.
+func getJSON(url string, v any) error {
+	val, ok := pokeCache.Get(url)
+	if ok {
+		return decodeJSON(bytes.NewReader(val), v)
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 200 {
+		return &statusError{url: url, code: res.StatusCode}
+	}
+	var buf bytes.Buffer
+	if res.ContentLength > 0 {
+		buf.Grow(int(res.ContentLength))
+	}
+	body := io.TeeReader(res.Body, &buf)
+	err = decodeJSON(body, v)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, body)
+	if err != nil {
+		return err
+	}
+	pokeCache.Add(url, buf.Bytes())
+	return nil
+}
+
+// decodeJSON decodes a single JSON value from r into v, streaming it when v
+// knows how to.
+func decodeJSON(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if s, ok := v.(streamDecoder); ok {
+		return s.decodeStream(dec)
+	}
+	return dec.Decode(v)
+}
+
+// decodeObject reads a JSON object, calling field for every key. field must
+// consume the key's value.
+func decodeObject(dec *json.Decoder, field func(key string) error) error {
+	err := expectDelim(dec, '{')
+	if err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		err = field(key)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeArray reads a JSON array, calling elem once per element. elem must
+// consume the element.
+func decodeArray(dec *json.Decoder, elem func() error) error {
+	err := expectDelim(dec, '[')
+	if err != nil {
+		return err
+	}
+	for dec.More() {
+		err = elem()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// skipValue discards the next value without buffering it whole.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
+
+// fetchBody returns the raw body at url, for callers that only want to warm
+// the cache.
+func fetchBody(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode > 200 {
+		return nil, &statusError{url: url, code: res.StatusCode}
+	}
+	return body, nil
+}
+
+// getAllJSON fetches every url concurrently, at most prefetchWorkers at a
+// time, and returns the results in the same order as urls.
+func getAllJSON[T any](urls []string) ([]T, error) {
+	results := make([]T, len(urls))
+	errs := make([]error, len(urls))
+	sem := make(chan struct{}, prefetchWorkers)
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = getJSON(url, &results[i])
+		}()
+	}
+	wg.Wait()
+	return results, errors.Join(errs...)
+}
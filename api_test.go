@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetJSON(t *testing.T) {
+	const body = `{"id":1,"encounter_method_rates":[{"rates":[1,{"b":"}"}]}],"name":"canalave-city-area","pokemon_encounters":[{"pokemon":{"name":"tentacool","url":"x"},"version_details":[]}],"names":[]}`
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path != "/location-area/canalave-city-area" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	pokeAPI = srv.URL + "/"
+
+	for i := 0; i < 2; i++ {
+		var view exploreView
+		err := getJSON(pokeAPI+"location-area/canalave-city-area", &view)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(view.PokemonEncounters) != 1 || view.PokemonEncounters[0].Pokemon.Name != "tentacool" {
+			t.Errorf("decoded %+v", view)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected second call to hit the cache, got %d requests", hits)
+	}
+	val, _ := pokeCache.Get(pokeAPI + "location-area/canalave-city-area")
+	if string(val) != body {
+		t.Errorf("expected raw body in cache, got %s", val)
+	}
+
+	var view exploreView
+	err := getJSON(pokeAPI+"location-area/nowhere", &view)
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func largeLocationArea(b *testing.B) []byte {
+	b.Helper()
+	raw := map[string]any{"name": "benchmark-area"}
+	var encounters []any
+	for i := 0; i < 80; i++ {
+		var versions []any
+		for v := 0; v < 12; v++ {
+			var details []any
+			for d := 0; d < 8; d++ {
+				details = append(details, map[string]any{
+					"min_level": d, "max_level": d + 3, "condition_values": []any{}, "chance": 10,
+					"method": map[string]any{"name": "walk", "url": "https://pokeapi.co/api/v2/encounter-method/1/"},
+				})
+			}
+			versions = append(versions, map[string]any{
+				"version":           map[string]any{"name": fmt.Sprintf("version-%d", v), "url": "https://pokeapi.co/api/v2/version/1/"},
+				"max_chance":        80,
+				"encounter_details": details,
+			})
+		}
+		encounters = append(encounters, map[string]any{
+			"pokemon":         map[string]any{"name": fmt.Sprintf("pokemon-%d", i), "url": "https://pokeapi.co/api/v2/pokemon/1/"},
+			"version_details": versions,
+		})
+	}
+	raw["pokemon_encounters"] = encounters
+	data, err := json.Marshal(raw)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkDecodeLocationArea(b *testing.B) {
+	data := largeLocationArea(b)
+	b.Run("ReadAllFull", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			body, err := io.ReadAll(bytes.NewReader(data))
+			if err != nil {
+				b.Fatal(err)
+			}
+			var area LocationArea
+			if err := json.Unmarshal(body, &area); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("StreamExploreView", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var view exploreView
+			if err := decodeJSON(bytes.NewReader(data), &view); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func largePokemon(b *testing.B) []byte {
+	b.Helper()
+	raw := map[string]any{"id": 25, "name": "benchmark-mon", "base_experience": 112}
+	var moves []any
+	for i := 0; i < 100; i++ {
+		var details []any
+		for g := 0; g < 25; g++ {
+			details = append(details, map[string]any{
+				"level_learned_at":  i % 50,
+				"move_learn_method": map[string]any{"name": "level-up", "url": "https://pokeapi.co/api/v2/move-learn-method/1/"},
+				"version_group":     map[string]any{"name": fmt.Sprintf("group-%d", g), "url": "https://pokeapi.co/api/v2/version-group/1/"},
+			})
+		}
+		moves = append(moves, map[string]any{
+			"move":                  map[string]any{"name": fmt.Sprintf("move-%d", i), "url": "https://pokeapi.co/api/v2/move/1/"},
+			"version_group_details": details,
+		})
+	}
+	raw["moves"] = moves
+	data, err := json.Marshal(raw)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+// BenchmarkGetJSON measures getJSON end to end against a local server,
+// including teeing the body into the cache. Every request uses a fresh url so
+// none are served from the cache.
+func BenchmarkGetJSON(b *testing.B) {
+	payloads := map[string][]byte{
+		"/location-area/": largeLocationArea(b),
+		"/pokemon/":       largePokemon(b),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for prefix, data := range payloads {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				w.Write(data)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	pokeAPI = srv.URL + "/"
+
+	n := 0
+	run := func(name, path string, v func() any) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				n++
+				if err := getJSON(fmt.Sprintf("%s%s%d", pokeAPI, path, n), v()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	run("LocationArea", "location-area/", func() any { return &LocationArea{} })
+	run("ExploreView", "location-area/", func() any { return &exploreView{} })
+	run("Pokemon", "pokemon/", func() any { return &Pokemon{} })
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxMoves is how many moves a Pokemon can know at once.
+	maxMoves = 4
+	// maxTurns ends a battle that neither side can win as a draw.
+	maxTurns = 100
+	// critChance is the one in n chance of a critical hit.
+	critChance = 24
+)
+
+// struggle is used when a Pokemon knows no damaging moves. The user takes a
+// quarter of its max HP in recoil.
+var struggle = func() Move {
+	power := 50
+	return Move{
+		Name:        "struggle",
+		Power:       &power,
+		Type:        NamedResource{Name: "normal"},
+		DamageClass: NamedResource{Name: "physical"},
+	}
+}()
+
+// battler is one side of a battle.
+type battler struct {
+	Label string
+	Level int
+	Stats map[string]int
+	HP    int
+	Types []PokemonType
+	Moves []Move
+}
+
+func (b *battler) hasType(name string) bool {
+	return slices.ContainsFunc(b.Types, func(t PokemonType) bool { return t.Name == name })
+}
+
+func (b *battler) fainted() bool {
+	return b.HP <= 0
+}
+
+// battle is a fight between two battlers. All randomness comes from rng so a
+// battle can be replayed from its seed.
+type battle struct {
+	out io.Writer
+	rng *rand.Rand
+	// choose picks the move attacker uses against defender this turn.
+	choose func(attacker, defender *battler) Move
+}
+
+func commandBattle(config *Config) error {
+	args, flags, err := parseFlags(config.Args, "auto")
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which Pokemon? Usage: battle <id|name> [<id|name>|wild <name>] [--seed <n>] [--auto]")
+		return nil
+	}
+	seed := time.Now().UnixNano()
+	if flags["seed"] != "" {
+		seed, err = strconv.ParseInt(flags["seed"], 10, 64)
+		if err != nil {
+			return fmt.Errorf("--seed must be a number, got %q", flags["seed"])
+		}
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	mine, ok := lookupCaught(args[0])
+	if !ok {
+		return nil
+	}
+	var opponent *battler
+	switch {
+	case len(args) > 2 && args[1] == "wild":
+		opponent, err = wildBattler(config, args[2], rng)
+	case len(args) > 1:
+		theirs, ok := lookupCaught(args[1])
+		if !ok {
+			return nil
+		}
+		opponent, err = caughtBattler(theirs, config.GameGroup)
+	case len(config.Encounters) > 0:
+		encounter := config.Encounters[rng.Intn(len(config.Encounters))]
+		opponent, err = wildBattler(config, encounter.Name, rng)
+	default:
+		fmt.Println("Explore an area to find a wild Pokemon, or pick an opponent")
+		return nil
+	}
+	if isNotFound(err) {
+		fmt.Println(args[len(args)-1], "is not a pokemon or correct id")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	player, err := caughtBattler(mine, config.GameGroup)
+	if err != nil {
+		return err
+	}
+
+	b := &battle{out: os.Stdout, rng: rng}
+	b.choose = func(attacker, defender *battler) Move {
+		switch {
+		case attacker != player:
+			return b.randomMove(attacker)
+		case flags["auto"] != "":
+			return bestMove(attacker, defender)
+		}
+		return chooseMove(config, attacker, defender)
+	}
+	fmt.Printf("Battle seed: %d\n", seed)
+	winner := b.run(player, opponent)
+	if winner == player {
+		fmt.Println(player.Label, "won!")
+	} else if winner == opponent {
+		fmt.Println(player.Label, "lost!")
+	}
+	return nil
+}
+
+// chooseMove asks the player which move to use, falling back to the one that
+// does the most damage on average.
+func chooseMove(config *Config, attacker, defender *battler) Move {
+	var names []string
+	for i, move := range attacker.Moves {
+		names = append(names, fmt.Sprintf("%d. %s", i+1, move.Name))
+	}
+	answer, ok := prompt(config, fmt.Sprintf("%s (%d HP): %s? ", attacker.Label, attacker.HP, strings.Join(names, ", ")))
+	if ok {
+		for i, move := range attacker.Moves {
+			if answer == strconv.Itoa(i+1) || strings.EqualFold(answer, move.Name) {
+				return move
+			}
+		}
+	}
+	return bestMove(attacker, defender)
+}
+
+// wildBattler fetches a wild Pokemon with random IVs and nature at a level it
+// can be found at in the last explored area.
+func wildBattler(config *Config, name string, rng *rand.Rand) (*battler, error) {
+	var pokemon Pokemon
+	err := getJSON(pokeAPI+"pokemon/"+name, &pokemon)
+	if err != nil {
+		return nil, err
+	}
+	ivs := make(map[string]int)
+	for _, stat := range pokemon.Stats {
+		ivs[stat.Stat.Name] = rng.Intn(maxIV + 1)
+	}
+	nature := natures[rng.Intn(len(natures))].Name
+	level := catchLevel(config, pokemon.Name, rng.Intn)
+	return newBattler("wild "+pokemon.Name, pokemon, level, ivs, nil, nature, levelUpMoves(pokemon, level, config.GameGroup))
+}
+
+// caughtBattler sends out a caught Pokemon with the moves it knows. Pokemon
+// from older saves that know no moves use their level-up learnset.
+func caughtBattler(c *CaughtPokemon, group string) (*battler, error) {
+	moves := c.KnownMoves
+	if len(moves) == 0 {
+		moves = levelUpMoves(*c.Pokemon, c.Level, group)
+	}
+	return newBattler(c.displayName(), *c.Pokemon, c.Level, c.IVs, c.EVs, c.Nature, moves)
+}
+
+// levelUpMoves lists the moves p learns by level up in group at or below
+// level, falling back to the newest version group.
+func levelUpMoves(p Pokemon, level int, group string) []string {
+	var moves []string
+	for _, learned := range learnset(p, cmp.Or(group, latestVersionGroup(p)), "level-up") {
+		if learned.Level <= level {
+			moves = append(moves, learned.Name)
+		}
+	}
+	return moves
+}
+
+// newBattler works out p's stats at level and fetches its types and moves.
+func newBattler(label string, p Pokemon, level int, ivs, evs map[string]int, nature string, moves []string) (*battler, error) {
+	stats := battleStats(p, level, ivs, evs, nature)
+	types, err := getTypes(pokemonTypes(p))
+	if err != nil {
+		return nil, err
+	}
+	known, err := battleMoves(moves)
+	if err != nil {
+		return nil, err
+	}
+	return &battler{Label: label, Level: level, Stats: stats, HP: stats["hp"], Types: types, Moves: known}, nil
+}
+
+// battleMoves fetches the last damaging moves of names, or struggle if none of
+// them do damage.
+func battleMoves(names []string) ([]Move, error) {
+	var urls []string
+	for _, name := range names {
+		urls = append(urls, pokeAPI+"move/"+name)
+	}
+	slices.Reverse(urls)
+	learned, err := getAllJSON[Move](urls)
+	if err != nil {
+		return nil, err
+	}
+	var moves []Move
+	for _, move := range learned {
+		if len(moves) == maxMoves {
+			break
+		}
+		if move.Power != nil && move.DamageClass.Name != "status" && !slices.ContainsFunc(moves, func(m Move) bool { return m.Name == move.Name }) {
+			moves = append(moves, move)
+		}
+	}
+	if len(moves) == 0 {
+		return []Move{struggle}, nil
+	}
+	slices.Reverse(moves)
+	return moves, nil
+}
+
+// battleStats calculates p's stats at level the way the main series games
+// do.
+func battleStats(p Pokemon, level int, ivs, evs map[string]int, nature string) map[string]int {
+	stats := make(map[string]int)
+	for _, stat := range p.Stats {
+		name := stat.Stat.Name
+		base := (2*stat.BaseStat + ivs[name] + evs[name]/4) * level / 100
+		if name == "hp" {
+			stats[name] = base + level + 10
+			continue
+		}
+		stats[name] = (base + 5) * natureModifier(nature, name) / 100
+	}
+	return stats
+}
+
+// natureModifier is the percentage nature scales stat by.
+func natureModifier(nature, stat string) int {
+	for _, n := range natures {
+		if n.Name != nature || n.Increase == n.Decrease {
+			continue
+		}
+		switch stat {
+		case n.Increase:
+			return 110
+		case n.Decrease:
+			return 90
+		}
+	}
+	return 100
+}
+
+// run has player and opponent take turns attacking until one faints, and
+// returns the winner, or nil if the battle ran out of turns.
+func (b *battle) run(player, opponent *battler) *battler {
+	fmt.Fprintf(b.out, "%s (Lv %d, %d HP) vs %s (Lv %d, %d HP)\n", player.Label, player.Level, player.HP, opponent.Label, opponent.Level, opponent.HP)
+	for turn := 1; turn <= maxTurns; turn++ {
+		fmt.Fprintf(b.out, "Turn %d:\n", turn)
+		moves := map[*battler]Move{
+			player:   b.choose(player, opponent),
+			opponent: b.choose(opponent, player),
+		}
+		first, second := player, opponent
+		if b.movesFirst(opponent, moves[opponent], player, moves[player]) {
+			first, second = opponent, player
+		}
+		for _, pair := range [][2]*battler{{first, second}, {second, first}} {
+			attacker, defender := pair[0], pair[1]
+			b.attack(attacker, defender, moves[attacker])
+			if defender.fainted() {
+				fmt.Fprintf(b.out, "   %s fainted!\n", defender.Label)
+				return attacker
+			}
+			if attacker.fainted() {
+				fmt.Fprintf(b.out, "   %s fainted!\n", attacker.Label)
+				return defender
+			}
+		}
+	}
+	fmt.Fprintln(b.out, "Neither side could win, the battle is a draw")
+	return nil
+}
+
+// movesFirst reports whether a goes before b, by move priority then speed,
+// with ties broken at random.
+func (b *battle) movesFirst(a *battler, aMove Move, other *battler, otherMove Move) bool {
+	if aMove.Priority != otherMove.Priority {
+		return aMove.Priority > otherMove.Priority
+	}
+	if a.Stats["speed"] != other.Stats["speed"] {
+		return a.Stats["speed"] > other.Stats["speed"]
+	}
+	return b.rng.Intn(2) == 0
+}
+
+func (b *battle) attack(attacker, defender *battler, move Move) {
+	fmt.Fprintf(b.out, "   %s used %s", attacker.Label, move.Name)
+	if move.Accuracy != nil && b.rng.Intn(100) >= *move.Accuracy {
+		fmt.Fprintln(b.out, ", but it missed!")
+		return
+	}
+	crit := b.rng.Intn(critChance) == 0
+	roll := 85 + b.rng.Intn(16)
+	damage, m := moveDamage(attacker, defender, move, crit, roll)
+	defender.HP = max(defender.HP-damage, 0)
+	fmt.Fprintf(b.out, " for %d damage", damage)
+	if crit && damage > 0 {
+		fmt.Fprint(b.out, ", a critical hit")
+	}
+	fmt.Fprintf(b.out, "%s (%d HP left)\n", effectivenessNote(m), defender.HP)
+	if move.Name == struggle.Name {
+		recoil := max(attacker.Stats["hp"]/4, 1)
+		attacker.HP = max(attacker.HP-recoil, 0)
+		fmt.Fprintf(b.out, "   %s took %d recoil damage (%d HP left)\n", attacker.Label, recoil, attacker.HP)
+	}
+}
+
+// moveDamage is the main series damage formula. roll is the random factor as
+// a percentage from 85 to 100. It also returns the type effectiveness.
+func moveDamage(attacker, defender *battler, move Move, crit bool, roll int) (int, float64) {
+	attack, defense := "attack", "defense"
+	if move.DamageClass.Name == "special" {
+		attack, defense = "special-attack", "special-defense"
+	}
+	power := 0
+	if move.Power != nil {
+		power = *move.Power
+	}
+	m := effectiveness(move.Type.Name, defender.Types)
+	if m == 0 {
+		return 0, m
+	}
+	base := (2*attacker.Level/5+2)*power*attacker.Stats[attack]/max(defender.Stats[defense], 1)/50 + 2
+	damage := float64(base) * float64(roll) / 100
+	if crit {
+		damage *= 1.5
+	}
+	if attacker.hasType(move.Type.Name) {
+		damage *= 1.5
+	}
+	damage *= m
+	return max(int(damage), 1), m
+}
+
+func (b *battle) randomMove(attacker *battler) Move {
+	return attacker.Moves[b.rng.Intn(len(attacker.Moves))]
+}
+
+// bestMove is the move that does the most damage to defender on average,
+// allowing for accuracy.
+func bestMove(attacker, defender *battler) Move {
+	best, bestDamage := attacker.Moves[0], -1.0
+	for _, move := range attacker.Moves {
+		damage, _ := moveDamage(attacker, defender, move, false, 100)
+		expected := float64(damage)
+		if move.Accuracy != nil {
+			expected *= float64(*move.Accuracy) / 100
+		}
+		if expected > bestDamage {
+			best, bestDamage = move, expected
+		}
+	}
+	return best
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBattleStats(t *testing.T) {
+	const fixture = `{"name": "garchomp", "stats": [
+		{"base_stat": 108, "stat": {"name": "hp"}},
+		{"base_stat": 130, "stat": {"name": "attack"}},
+		{"base_stat": 95, "stat": {"name": "defense"}},
+		{"base_stat": 80, "stat": {"name": "special-attack"}}
+	]}`
+	var garchomp Pokemon
+	err := json.Unmarshal([]byte(fixture), &garchomp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ivs := map[string]int{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16}
+	evs := map[string]int{"hp": 74, "attack": 190, "defense": 91, "special-attack": 48}
+	stats := battleStats(garchomp, 78, ivs, evs, "adamant")
+	expected := map[string]int{"hp": 289, "attack": 278, "defense": 193, "special-attack": 135}
+	for name, value := range expected {
+		if stats[name] != value {
+			t.Errorf("%s: got %d, expected %d", name, stats[name], value)
+		}
+	}
+}
+
+func testMove(name, moveType string, power int) Move {
+	return Move{
+		Name:        name,
+		Power:       &power,
+		Type:        NamedResource{Name: moveType},
+		DamageClass: NamedResource{Name: "physical"},
+	}
+}
+
+func TestMoveDamage(t *testing.T) {
+	stats := map[string]int{"hp": 100, "attack": 100, "defense": 100, "speed": 50}
+	attacker := &battler{Label: "charmander", Level: 50, Stats: stats, Types: decodeTypes(t, fireType)}
+	defender := &battler{Label: "pidgey", Level: 50, Stats: stats, Types: decodeTypes(t, flyingType)}
+	cases := []struct {
+		move     Move
+		crit     bool
+		roll     int
+		expected int
+	}{
+		{testMove("tackle", "normal", 80), false, 100, 37},
+		{testMove("tackle", "normal", 80), false, 85, 31},
+		{testMove("tackle", "normal", 80), true, 100, 55},
+		{testMove("ember", "fire", 80), false, 100, 55},
+		{testMove("rock-slide", "rock", 80), false, 100, 74},
+		{testMove("vine-whip", "grass", 80), false, 100, 18},
+		{testMove("earthquake", "ground", 80), false, 100, 0},
+	}
+	for _, c := range cases {
+		actual, _ := moveDamage(attacker, defender, c.move, c.crit, c.roll)
+		if actual != c.expected {
+			t.Errorf("%s (crit %v, roll %d): got %d, expected %d", c.move.Name, c.crit, c.roll, actual, c.expected)
+		}
+	}
+}
+
+func TestBattleSeed(t *testing.T) {
+	replay := func(seed int64) string {
+		stats := map[string]int{"hp": 60, "attack": 60, "defense": 50, "speed": 50}
+		player := &battler{Label: "charmander", Level: 20, Stats: stats, HP: 60, Types: decodeTypes(t, fireType),
+			Moves: []Move{testMove("ember", "fire", 40), testMove("scratch", "normal", 40)}}
+		opponent := &battler{Label: "wild pidgey", Level: 20, Stats: stats, HP: 60, Types: decodeTypes(t, flyingType),
+			Moves: []Move{testMove("gust", "flying", 40), struggle}}
+		var out strings.Builder
+		b := &battle{out: &out, rng: rand.New(rand.NewSource(seed))}
+		b.choose = func(attacker, _ *battler) Move { return b.randomMove(attacker) }
+		winner := b.run(player, opponent)
+		if winner == nil {
+			t.Errorf("seed %d: expected a winner", seed)
+		}
+		return out.String()
+	}
+	for seed := range int64(5) {
+		first, second := replay(seed), replay(seed)
+		if first != second {
+			t.Errorf("seed %d gave different battles:\n%s\n%s", seed, first, second)
+		}
+		if !strings.Contains(first, "fainted!") {
+			t.Errorf("seed %d: expected someone to faint:\n%s", seed, first)
+		}
+	}
+	if replay(1) == replay(2) {
+		t.Errorf("expected different seeds to give different battles")
+	}
+}
+
+func TestWildBattlerSeed(t *testing.T) {
+	fixtures := map[string]string{
+		"/pokemon/pidgey": `{"name": "pidgey", "types": [{"type": {"name": "flying"}}], "stats": [
+			{"base_stat": 40, "stat": {"name": "hp"}},
+			{"base_stat": 45, "stat": {"name": "attack"}},
+			{"base_stat": 56, "stat": {"name": "speed"}}
+		], "moves": [
+			{"move": {"name": "tackle"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+			{"move": {"name": "gust"}, "version_group_details": [{"level_learned_at": 5, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]}
+		]}`,
+		"/type/flying": flyingType,
+		"/move/tackle": `{"name": "tackle", "power": 40, "type": {"name": "normal"}, "damage_class": {"name": "physical"}}`,
+		"/move/gust":   `{"name": "gust", "power": 40, "type": {"name": "flying"}, "damage_class": {"name": "special"}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	pokeAPI = srv.URL + "/"
+
+	config := &Config{GameGroup: "red-blue", Encounters: []encounterSummary{{Name: "pidgey", MinLevel: 2, MaxLevel: 7}}}
+	levels := make(map[int]bool)
+	for seed := range int64(20) {
+		first, err := wildBattler(config, "pidgey", rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, err := wildBattler(config, "pidgey", rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var firstMoves, secondMoves []string
+		for _, move := range first.Moves {
+			firstMoves = append(firstMoves, move.Name)
+		}
+		for _, move := range second.Moves {
+			secondMoves = append(secondMoves, move.Name)
+		}
+		if first.Level != second.Level || first.HP != second.HP || !slices.Equal(firstMoves, secondMoves) {
+			t.Errorf("seed %d gave different wild pidgey: Lv %d %d HP %v and Lv %d %d HP %v",
+				seed, first.Level, first.HP, firstMoves, second.Level, second.HP, secondMoves)
+		}
+		if first.Level < 2 || first.Level > 7 {
+			t.Errorf("seed %d: level %d is outside 2-7", seed, first.Level)
+		}
+		levels[first.Level] = true
+	}
+	if len(levels) < 2 {
+		t.Errorf("expected different seeds to give different levels, got %v", levels)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIV is the highest individual value a stat can roll.
+const maxIV = 31
+
+// CaughtPokemon is one Pokemon the player owns. Pokemon points at the species
+// data shared by every caught Pokemon of that species.
+type CaughtPokemon struct {
+	*Pokemon     `json:"-"`
+	ID           int
+	Nickname     string
+	CaughtAt     time.Time
+	Location     string
+	Level        int
+	Exp          int
+	Nature       string
+	IVs          map[string]int
+	EVs          map[string]int
+	CurrentStats map[string]int
+	KnownMoves   []string
+	History      []string
+	StatsHistory []StatSnapshot
+}
+
+// StatSnapshot records a caught Pokemon's stats at some point in its life.
+type StatSnapshot struct {
+	Species string
+	Level   int
+	At      time.Time
+	Stats   map[string]int
+}
+
+// Nature raises one stat by 10% and lowers another. Natures that raise and
+// lower the same stat have no effect.
+type Nature struct {
+	Name     string
+	Increase string
+	Decrease string
+}
+
+var natures = []Nature{
+	{"hardy", "attack", "attack"},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "defense", "defense"},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "speed", "speed"},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "special-attack", "special-attack"},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "special-defense", "special-defense"},
+}
+
+// addCaught records a newly caught pokemon with random IVs and nature, giving
+// it the next free ID.
+func addCaught(pokemon *Pokemon, level int, location string, now time.Time) *CaughtPokemon {
+	if _, ok := pokedex.caughtSpecies[pokemon.Name]; !ok {
+		pokedex.caughtSpecies[pokemon.Name] = pokemon
+	}
+	pokemon = pokedex.caughtSpecies[pokemon.Name]
+	ivs := make(map[string]int)
+	for _, stat := range pokemon.Stats {
+		ivs[stat.Stat.Name] = rand.Intn(maxIV + 1)
+	}
+	pokedex.nextID++
+	caught := &CaughtPokemon{
+		Pokemon:  pokemon,
+		ID:       pokedex.nextID,
+		CaughtAt: now,
+		Location: location,
+		Level:    level,
+		Nature:   natures[rand.Intn(len(natures))].Name,
+		IVs:      ivs,
+		EVs:      make(map[string]int),
+		History:  []string{fmt.Sprintf("%s: caught at Lv %d in %s", now.Format(time.DateOnly), level, location)},
+	}
+	caught.recalcStats()
+	caught.StatsHistory = []StatSnapshot{snapshotStats(caught, now)}
+	pokedex.capturedPokemon[caught.ID] = caught
+	return caught
+}
+
+// displayName is the Pokemon's nickname with its species, or just the
+// species if it has no nickname.
+func (c *CaughtPokemon) displayName() string {
+	if c.Nickname == "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%s (%s)", c.Nickname, c.Name)
+}
+
+// findCaught returns the caught Pokemon matching an ID, nickname or species
+// name.
+func findCaught(idOrName string) []*CaughtPokemon {
+	id, err := strconv.Atoi(idOrName)
+	if err == nil {
+		caught, ok := pokedex.capturedPokemon[id]
+		if !ok {
+			return nil
+		}
+		return []*CaughtPokemon{caught}
+	}
+	var found []*CaughtPokemon
+	for _, caught := range sortedCaught() {
+		if caught.Name == idOrName || strings.EqualFold(caught.Nickname, idOrName) {
+			found = append(found, caught)
+		}
+	}
+	return found
+}
+
+// caughtName returns the name of the caught Pokemon nicknamed name, so
+// commands that look up a species also take nicknames. Numbers are left
+// alone since those commands read them as national dex numbers.
+func caughtName(name string) string {
+	if _, err := strconv.Atoi(name); err == nil {
+		return name
+	}
+	found := findCaught(name)
+	if len(found) == 0 {
+		return name
+	}
+	return found[0].Name
+}
+
+// lookupCaught is findCaught for commands that need exactly one Pokemon. It
+// tells the player what went wrong when there is none or more than one.
+func lookupCaught(idOrName string) (*CaughtPokemon, bool) {
+	found := findCaught(idOrName)
+	switch len(found) {
+	case 0:
+		fmt.Printf("You have not caught a %v or invalid name\n", idOrName)
+		return nil, false
+	case 1:
+		return found[0], true
+	}
+	fmt.Printf("You have %d %s, pick one by ID:\n", len(found), idOrName)
+	for _, caught := range found {
+		fmt.Printf(" - %d: %s Lv %d\n", caught.ID, caught.displayName(), caught.Level)
+	}
+	return nil, false
+}
+
+func sortedCaught() []*CaughtPokemon {
+	ids := slices.Sorted(maps.Keys(pokedex.capturedPokemon))
+	caught := make([]*CaughtPokemon, 0, len(ids))
+	for _, id := range ids {
+		caught = append(caught, pokedex.capturedPokemon[id])
+	}
+	return caught
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddCaught(t *testing.T) {
+	resetPokedex()
+	now := time.Now()
+
+	first := addCaught(&Pokemon{Name: "pikachu"}, 5, "viridian-forest-area", now)
+	second := addCaught(&Pokemon{Name: "pikachu"}, 7, "power-plant-area", now)
+	addCaught(&Pokemon{Name: "pidgey"}, 3, "route-1-area", now)
+
+	if first.ID == second.ID {
+		t.Errorf("expected unique IDs, both were %d", first.ID)
+	}
+	if first.Pokemon != second.Pokemon {
+		t.Errorf("expected both pikachu to share species data")
+	}
+	if len(pokedex.capturedPokemon) != 3 || len(pokedex.caughtSpecies) != 2 {
+		t.Errorf("expected 3 owned and 2 species, got %d and %d", len(pokedex.capturedPokemon), len(pokedex.caughtSpecies))
+	}
+
+	cases := []struct {
+		input    string
+		expected []int
+	}{
+		{"pikachu", []int{first.ID, second.ID}},
+		{"2", []int{second.ID}},
+		{"pidgey", []int{3}},
+		{"9", nil},
+		{"mew", nil},
+	}
+	for _, c := range cases {
+		found := findCaught(c.input)
+		if len(found) != len(c.expected) {
+			t.Errorf("findCaught(%s) found %d, expected %d", c.input, len(found), len(c.expected))
+			continue
+		}
+		for i := range found {
+			if found[i].ID != c.expected[i] {
+				t.Errorf("findCaught(%s)[%d] = %d, expected %d", c.input, i, found[i].ID, c.expected[i])
+			}
+		}
+	}
+}
+
+func TestNicknameLookup(t *testing.T) {
+	resetPokedex()
+	sparky := addCaught(&Pokemon{Name: "pikachu"}, 5, "viridian-forest-area", time.Now())
+	other := addCaught(&Pokemon{Name: "pikachu"}, 5, "viridian-forest-area", time.Now())
+
+	if !setNickname(sparky, "Sparky") {
+		t.Fatalf("expected nickname to be set")
+	}
+	found := findCaught("sparky")
+	if len(found) != 1 || found[0] != sparky {
+		t.Errorf("expected to find Sparky by nickname, got %v", found)
+	}
+	for input, expected := range map[string]string{"sparky": "pikachu", "raichu": "raichu", "1": "1"} {
+		if name := caughtName(input); name != expected {
+			t.Errorf("caughtName(%s) = %s, expected %s", input, name, expected)
+		}
+	}
+	if sparky.displayName() != "Sparky (pikachu)" || other.displayName() != "pikachu" {
+		t.Errorf("unexpected display names %q and %q", sparky.displayName(), other.displayName())
+	}
+	for _, name := range []string{"Sparky", "Pikachu", "42", "Thunderstruck!"} {
+		if setNickname(other, name) {
+			t.Errorf("expected nickname %q to be refused", name)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+func commandCompare(config *Config) error {
+	if len(config.Args) < 2 {
+		fmt.Println("Which Pokemon? Usage: compare <id|name> <id|name> [id|name...]")
+		return nil
+	}
+	var pokemon []*Pokemon
+	var labels []string
+	var types [][]PokemonType
+	for _, arg := range config.Args {
+		p, label, err := resolvePokemon(arg)
+		if isNotFound(err) {
+			fmt.Println(arg, "is not a pokemon or correct id")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		t, err := getTypes(pokemonTypes(*p))
+		if err != nil {
+			return err
+		}
+		pokemon = append(pokemon, p)
+		labels = append(labels, label)
+		types = append(types, t)
+	}
+
+	printComparison(os.Stdout, labels, pokemon)
+	fmt.Println("Type matchups (best attacking type):")
+	for i := range pokemon {
+		for j := range pokemon {
+			if i == j {
+				continue
+			}
+			attackType, m := bestAttackType(pokemon[i], types[j])
+			fmt.Printf("   %s -> %s: %s with %s%s\n", labels[i], labels[j], formatMultiplier(m), attackType, effectivenessNote(m))
+		}
+	}
+	shared, unique := compareAbilities(pokemon)
+	fmt.Println("Shared abilities:", listOrNone(shared))
+	for i, abilities := range unique {
+		fmt.Printf("Only %s: %s\n", labels[i], listOrNone(abilities))
+	}
+	return nil
+}
+
+// printComparison shows the Pokemon's base stats side by side with how far
+// each is from the first, marking the highest of each stat with a *.
+func printComparison(w io.Writer, labels []string, pokemon []*Pokemon) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(heading string, cell func(i int, p *Pokemon) string) {
+		cells := []string{heading}
+		for i, p := range pokemon {
+			cells = append(cells, cell(i, p))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	statRow := func(heading string, value func(p *Pokemon) int) {
+		values := make([]int, len(pokemon))
+		for i, p := range pokemon {
+			values[i] = value(p)
+		}
+		highest := slices.Max(values)
+		tied := slices.Min(values) == highest
+		row(heading, func(i int, _ *Pokemon) string {
+			cell := fmt.Sprint(values[i])
+			if i > 0 && values[i] != values[0] {
+				cell += fmt.Sprintf(" (%+d)", values[i]-values[0])
+			}
+			if values[i] == highest && !tied {
+				cell += " *"
+			}
+			return cell
+		})
+	}
+	row("", func(i int, _ *Pokemon) string { return labels[i] })
+	for _, column := range statColumns {
+		statRow(column[1], func(p *Pokemon) int { return baseStat(p, column[0]) })
+	}
+	statRow("BST", baseStatTotal)
+	row("Type", func(_ int, p *Pokemon) string { return strings.Join(pokemonTypes(*p), "/") })
+	row("Height", func(_ int, p *Pokemon) string { return formatHeight(p.Height) })
+	row("Weight", func(_ int, p *Pokemon) string { return formatWeight(p.Weight) })
+	tw.Flush()
+}
+
+// bestAttackType is the attacker's type that does the most damage to the
+// defender, and its multiplier.
+func bestAttackType(attacker *Pokemon, defender []PokemonType) (string, float64) {
+	best, bestM := "", -1.0
+	for _, attackType := range pokemonTypes(*attacker) {
+		if m := effectiveness(attackType, defender); m > bestM {
+			best, bestM = attackType, m
+		}
+	}
+	return best, bestM
+}
+
+// compareAbilities returns the abilities every Pokemon has, and for each
+// Pokemon the abilities none of the others have.
+func compareAbilities(pokemon []*Pokemon) ([]string, [][]string) {
+	has := make([][]string, len(pokemon))
+	for i, p := range pokemon {
+		for _, ability := range p.Abilities {
+			has[i] = append(has[i], ability.Ability.Name)
+		}
+	}
+	var shared []string
+	unique := make([][]string, len(pokemon))
+	for i, abilities := range has {
+		for _, ability := range abilities {
+			count := 0
+			for _, other := range has {
+				if slices.Contains(other, ability) {
+					count++
+				}
+			}
+			switch {
+			case count == len(pokemon) && !slices.Contains(shared, ability):
+				shared = append(shared, ability)
+			case count == 1:
+				unique[i] = append(unique[i], ability)
+			}
+		}
+	}
+	return shared, unique
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPrintComparison(t *testing.T) {
+	pikachu := decodeFixture[Pokemon](t, `{"name": "pikachu", "height": 4, "weight": 60, "types": [{"type": {"name": "electric"}}], "stats": [
+		{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 90, "stat": {"name": "speed"}}]}`)
+	raichu := decodeFixture[Pokemon](t, `{"name": "raichu", "height": 8, "weight": 300, "types": [{"type": {"name": "electric"}}], "stats": [
+		{"base_stat": 60, "stat": {"name": "hp"}}, {"base_stat": 110, "stat": {"name": "speed"}}]}`)
+	var out strings.Builder
+	printComparison(&out, []string{"pikachu", "raichu"}, []*Pokemon{&pikachu, &raichu})
+
+	expected := []string{
+		"HP      35                 60 (+25) *",
+		"Atk     0                  0",
+		"Spe     90                 110 (+20) *",
+		"BST     125                170 (+45) *",
+		"Type    electric           electric",
+		"Weight  6.0 kg (13.2 lbs)  30.0 kg (66.1 lbs)",
+	}
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("expected %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestCompareAbilities(t *testing.T) {
+	ability := func(names ...string) *Pokemon {
+		var abilities []string
+		for _, name := range names {
+			abilities = append(abilities, `{"ability": {"name": "`+name+`"}}`)
+		}
+		p := decodeFixture[Pokemon](t, `{"abilities": [`+strings.Join(abilities, ", ")+`]}`)
+		return &p
+	}
+	shared, unique := compareAbilities([]*Pokemon{
+		ability("static", "lightning-rod"),
+		ability("static", "surge-surfer"),
+		ability("static", "lightning-rod", "volt-absorb"),
+	})
+	if !slices.Equal(shared, []string{"static"}) {
+		t.Errorf("unexpected shared abilities %v", shared)
+	}
+	expected := [][]string{nil, {"surge-surfer"}, {"volt-absorb"}}
+	for i := range expected {
+		if !slices.Equal(unique[i], expected[i]) {
+			t.Errorf("pokemon %d: got unique abilities %v, expected %v", i, unique[i], expected[i])
+		}
+	}
+}
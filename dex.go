@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SeenPokemon is what the Pokedex knows about a Pokemon it has come across,
+// caught or not. Types are only known once a catch has been attempted.
+type SeenPokemon struct {
+	Name  string
+	ID    int
+	Types []string
+	Area  string
+}
+
+// markSeen records p in the Pokedex, keeping anything already known about it.
+func markSeen(p SeenPokemon) {
+	seen, ok := pokedex.seenPokemon[p.Name]
+	if !ok {
+		pokedex.seenPokemon[p.Name] = p
+		return
+	}
+	if seen.ID == 0 {
+		seen.ID = p.ID
+	}
+	if len(p.Types) > 0 {
+		seen.Types = p.Types
+	}
+	if seen.Area == "" {
+		seen.Area = p.Area
+	}
+	pokedex.seenPokemon[p.Name] = seen
+}
+
+// findSeen looks a seen Pokemon up by name or national dex number.
+func findSeen(nameOrID string) (SeenPokemon, bool) {
+	id, err := strconv.Atoi(nameOrID)
+	if err != nil {
+		seen, ok := pokedex.seenPokemon[nameOrID]
+		return seen, ok
+	}
+	for _, seen := range pokedex.seenPokemon {
+		if seen.ID == id {
+			return seen, true
+		}
+	}
+	return SeenPokemon{}, false
+}
+
+func commandDex(config *Config) error {
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which Pokemon? Usage: dex <name|id> [--lang <code>] [--version <game>]")
+		return nil
+	}
+	seen, ok := findSeen(caughtName(args[0]))
+	if !ok {
+		fmt.Printf("You haven't seen %s yet. Explore to find it!\n", args[0])
+		return nil
+	}
+	var pokemon struct {
+		Species NamedResource `json:"species"`
+	}
+	err = getJSON(pokeAPI+"pokemon/"+seen.Name, &pokemon)
+	if err != nil {
+		return err
+	}
+	var species PokemonSpecies
+	err = getJSON(pokemon.Species.URL, &species)
+	if err != nil {
+		return err
+	}
+
+	status := "seen"
+	if _, ok := pokedex.caughtSpecies[seen.Name]; ok {
+		status = "caught"
+	}
+	fmt.Printf("#%03d %s (%s)\n", species.ID, seen.Name, status)
+	if len(seen.Types) > 0 {
+		fmt.Println("Type:", strings.Join(seen.Types, "/"))
+	}
+	if seen.Area != "" {
+		fmt.Println("First seen in:", seen.Area)
+	}
+	printSpecies(species, speciesOptions(config, flags))
+	return nil
+}
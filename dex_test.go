@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestMarkSeen(t *testing.T) {
+	pokedex.seenPokemon = make(map[string]SeenPokemon)
+	markSeen(SeenPokemon{Name: "pidgey", ID: 16, Area: "route-1-area"})
+	markSeen(SeenPokemon{Name: "pidgey", ID: 16, Types: []string{"normal", "flying"}})
+
+	seen, ok := findSeen("16")
+	if !ok {
+		t.Fatalf("expected to find pidgey by id")
+	}
+	if seen.Area != "route-1-area" || len(seen.Types) != 2 {
+		t.Errorf("expected seen entry to be merged, got %+v", seen)
+	}
+	if _, ok := findSeen("rattata"); ok {
+		t.Errorf("expected rattata not to be seen")
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+type EvolutionChain struct {
+	ID    int       `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
+
+type ChainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          NamedResource     `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail is one way of evolving. Unset conditions are null in the
+// API, hence the pointers.
+type EvolutionDetail struct {
+	Trigger               NamedResource  `json:"trigger"`
+	Item                  *NamedResource `json:"item"`
+	HeldItem              *NamedResource `json:"held_item"`
+	KnownMove             *NamedResource `json:"known_move"`
+	KnownMoveType         *NamedResource `json:"known_move_type"`
+	Location              *NamedResource `json:"location"`
+	PartySpecies          *NamedResource `json:"party_species"`
+	PartyType             *NamedResource `json:"party_type"`
+	TradeSpecies          *NamedResource `json:"trade_species"`
+	Gender                *int           `json:"gender"`
+	MinLevel              *int           `json:"min_level"`
+	MinHappiness          *int           `json:"min_happiness"`
+	MinBeauty             *int           `json:"min_beauty"`
+	MinAffection          *int           `json:"min_affection"`
+	RelativePhysicalStats *int           `json:"relative_physical_stats"`
+	NeedsOverworldRain    bool           `json:"needs_overworld_rain"`
+	TimeOfDay             string         `json:"time_of_day"`
+	TurnUpsideDown        bool           `json:"turn_upside_down"`
+}
+
+func commandEvolutions(config *Config) error {
+	if config.Param == "" {
+		fmt.Println("Which Pokemon? Usage: evolutions <name>")
+		return nil
+	}
+	species, err := getSpecies(caughtName(config.Param))
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not a pokemon or correct id")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var chain EvolutionChain
+	err = getJSON(species.EvolutionChain.URL, &chain)
+	if err != nil {
+		return err
+	}
+	printChain(os.Stdout, chain.Chain, dexStatus)
+	return nil
+}
+
+// getSpecies fetches the species of a pokemon given its species or pokemon
+// name. Most pokemon share their species' name but forms such as
+// deoxys-attack don't, so those go through the pokemon endpoint.
+func getSpecies(name string) (PokemonSpecies, error) {
+	var species PokemonSpecies
+	err := getJSON(pokeAPI+"pokemon-species/"+name, &species)
+	if !isNotFound(err) {
+		return species, err
+	}
+	var pokemon struct {
+		Species NamedResource `json:"species"`
+	}
+	err = getJSON(pokeAPI+"pokemon/"+name, &pokemon)
+	if err != nil {
+		return species, err
+	}
+	err = getJSON(pokemon.Species.URL, &species)
+	return species, err
+}
+
+// dexStatus reports whether a species has been caught or seen.
+func dexStatus(species string) string {
+	for _, val := range pokedex.caughtSpecies {
+		if val.Species.Name == species {
+			return "caught"
+		}
+	}
+	if _, ok := pokedex.seenPokemon[species]; ok {
+		return "seen"
+	}
+	return ""
+}
+
+// printChain draws link and everything it evolves into as a tree.
+func printChain(w io.Writer, link ChainLink, status func(species string) string) {
+	fmt.Fprintln(w, chainLabel(link, status))
+	printEvolutions(w, link.EvolvesTo, "", status)
+}
+
+func printEvolutions(w io.Writer, links []ChainLink, indent string, status func(species string) string) {
+	for i, link := range links {
+		branch, next := "|- ", "|  "
+		if i == len(links)-1 {
+			branch, next = "`- ", "   "
+		}
+		fmt.Fprintf(w, "%s%s%s (%s)\n", indent, branch, chainLabel(link, status), describeEvolutions(link.EvolutionDetails))
+		printEvolutions(w, link.EvolvesTo, indent+next, status)
+	}
+}
+
+func chainLabel(link ChainLink, status func(species string) string) string {
+	label := link.Species.Name
+	if link.IsBaby {
+		label += " (baby)"
+	}
+	if s := status(link.Species.Name); s != "" {
+		label += " [" + s + "]"
+	}
+	return label
+}
+
+// describeEvolutions joins the different ways of evolving, which often only
+// differ between games, dropping duplicates.
+func describeEvolutions(details []EvolutionDetail) string {
+	var ways []string
+	for _, detail := range details {
+		way := describeEvolution(detail)
+		if !slices.Contains(ways, way) {
+			ways = append(ways, way)
+		}
+	}
+	if len(ways) == 0 {
+		return "unknown"
+	}
+	return strings.Join(ways, " or ")
+}
+
+func describeEvolution(d EvolutionDetail) string {
+	var parts []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		parts = append(parts, "use "+d.Item.Name)
+	case "trade":
+		parts = append(parts, "trade")
+		if d.TradeSpecies != nil {
+			parts = append(parts, "for "+d.TradeSpecies.Name)
+		}
+	default:
+		parts = append(parts, strings.ReplaceAll(d.Trigger.Name, "-", " "))
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("at level %d", *d.MinLevel))
+		}
+	}
+	if d.Item != nil && d.Trigger.Name != "use-item" {
+		parts = append(parts, "using "+d.Item.Name)
+	}
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("with friendship %d+", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("with affection %d+", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("with beauty %d+", *d.MinBeauty))
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.PartySpecies != nil {
+		parts = append(parts, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		parts = append(parts, "with a "+d.PartyType.Name+" type in the party")
+	}
+	if d.Gender != nil {
+		if *d.Gender == 1 {
+			parts = append(parts, "if female")
+		} else {
+			parts = append(parts, "if male")
+		}
+	}
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, "if attack > defense")
+		case 0:
+			parts = append(parts, "if attack = defense")
+		case -1:
+			parts = append(parts, "if attack < defense")
+		}
+	}
+	switch d.TimeOfDay {
+	case "":
+	case "day":
+		parts = append(parts, "during the day")
+	default:
+		parts = append(parts, "at "+d.TimeOfDay)
+	}
+	if d.NeedsOverworldRain {
+		parts = append(parts, "in the rain")
+	}
+	if d.TurnUpsideDown {
+		parts = append(parts, "holding the console upside down")
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const eeveeChain = `{"id": 67, "chain": {
+	"is_baby": false,
+	"species": {"name": "eevee"},
+	"evolution_details": [],
+	"evolves_to": [
+		{"species": {"name": "vaporeon"}, "evolution_details": [
+			{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null, "time_of_day": ""}
+		], "evolves_to": []},
+		{"species": {"name": "espeon"}, "evolution_details": [
+			{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "day"}
+		], "evolves_to": []},
+		{"species": {"name": "sylveon"}, "evolution_details": [
+			{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}, "min_affection": 2, "time_of_day": ""},
+			{"trigger": {"name": "level-up"}, "known_move_type": {"name": "fairy"}, "min_affection": 2, "time_of_day": ""}
+		], "evolves_to": []}
+	]
+}}`
+
+const bulbasaurChain = `{"id": 1, "chain": {
+	"species": {"name": "bulbasaur"},
+	"evolves_to": [
+		{"species": {"name": "ivysaur"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}], "evolves_to": [
+			{"species": {"name": "venusaur"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 32}], "evolves_to": []}
+		]}
+	]
+}}`
+
+func TestPrintChain(t *testing.T) {
+	status := func(species string) string {
+		switch species {
+		case "eevee", "ivysaur":
+			return "caught"
+		case "espeon":
+			return "seen"
+		}
+		return ""
+	}
+	cases := []struct {
+		chain    string
+		expected string
+	}{
+		{eeveeChain, `eevee [caught]
+|- vaporeon (use water-stone)
+|- espeon [seen] (level up with friendship 160+ during the day)
+` + "`- " + `sylveon (level up with affection 2+ knowing a fairy move)
+`},
+		{bulbasaurChain, `bulbasaur
+` + "`- " + `ivysaur [caught] (level 16)
+   ` + "`- " + `venusaur (level 32)
+`},
+	}
+	for _, c := range cases {
+		var chain EvolutionChain
+		err := decodeJSON(strings.NewReader(c.chain), &chain)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var out strings.Builder
+		printChain(&out, chain.Chain, status)
+		if out.String() != c.expected {
+			t.Errorf("got\n%s\nexpected\n%s", out.String(), c.expected)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func commandEvolve(config *Config) error {
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which Pokemon? Usage: evolve <id|name> [into] [--item <item>]")
+		return nil
+	}
+	caught, ok := lookupCaught(args[0])
+	if !ok {
+		return nil
+	}
+	var species PokemonSpecies
+	err = getJSON(caught.Species.URL, &species)
+	if err != nil {
+		return err
+	}
+	var chain EvolutionChain
+	err = getJSON(species.EvolutionChain.URL, &chain)
+	if err != nil {
+		return err
+	}
+	link, ok := findChainLink(chain.Chain, caught.Species.Name)
+	if !ok || len(link.EvolvesTo) == 0 {
+		fmt.Println(caught.displayName(), "does not evolve")
+		return nil
+	}
+
+	now := time.Now()
+	var ready []string
+	for _, next := range link.EvolvesTo {
+		if len(args) > 1 && next.Species.Name != args[1] {
+			continue
+		}
+		blocker := evolutionBlocker(next.EvolutionDetails, caught, flags["item"], now)
+		if blocker != "" {
+			fmt.Printf("Can't evolve into %s yet: %s\n", next.Species.Name, blocker)
+			continue
+		}
+		ready = append(ready, next.Species.Name)
+	}
+	switch {
+	case len(ready) == 0 && len(args) > 1 && !chainHas(link.EvolvesTo, args[1]):
+		fmt.Println(caught.Name, "can't evolve into", args[1])
+		return nil
+	case len(ready) == 0:
+		return nil
+	case len(ready) > 1:
+		fmt.Printf("%s can evolve into %s. Pick one: evolve %s <into>\n", caught.Name, strings.Join(ready, " or "), args[0])
+		return nil
+	}
+
+	var evolved Pokemon
+	err = getJSON(pokeAPI+"pokemon/"+ready[0], &evolved)
+	if err != nil {
+		return err
+	}
+	if _, ok := pokedex.caughtSpecies[evolved.Name]; !ok {
+		pokedex.caughtSpecies[evolved.Name] = &evolved
+	}
+	from := caught.displayName()
+	evolveInto(caught, pokedex.caughtSpecies[evolved.Name], flags["item"], now)
+	markSeen(SeenPokemon{Name: evolved.Name, ID: evolved.ID, Types: pokemonTypes(evolved)})
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", from, evolved.Name)
+	return nil
+}
+
+// evolveInto swaps caught's species data for evolved's, keeping everything
+// that belongs to the individual and noting the evolution in its history.
+func evolveInto(caught *CaughtPokemon, evolved *Pokemon, item string, now time.Time) {
+	event := fmt.Sprintf("%s: evolved from %s into %s at Lv %d", now.Format(time.DateOnly), caught.Name, evolved.Name, caught.Level)
+	if item != "" {
+		event += " using " + item
+	}
+	caught.Pokemon = evolved
+	caught.History = append(caught.History, event)
+	caught.recalcStats()
+	caught.StatsHistory = append(caught.StatsHistory, snapshotStats(caught, now))
+}
+
+func findChainLink(link ChainLink, species string) (ChainLink, bool) {
+	if link.Species.Name == species {
+		return link, true
+	}
+	for _, next := range link.EvolvesTo {
+		found, ok := findChainLink(next, species)
+		if ok {
+			return found, true
+		}
+	}
+	return ChainLink{}, false
+}
+
+func chainHas(links []ChainLink, species string) bool {
+	for _, link := range links {
+		if link.Species.Name == species {
+			return true
+		}
+	}
+	return false
+}
+
+// evolutionBlocker returns why caught can't evolve by any of details, or ""
+// if one of them is met. Only level, item and time of day are tracked, so any
+// other condition counts as unmet.
+func evolutionBlocker(details []EvolutionDetail, caught *CaughtPokemon, item string, now time.Time) string {
+	reason := "unknown evolution method"
+	for _, d := range details {
+		r := detailBlocker(d, caught, item, now)
+		if r == "" {
+			return ""
+		}
+		reason = r
+	}
+	return reason
+}
+
+func detailBlocker(d EvolutionDetail, caught *CaughtPokemon, item string, now time.Time) string {
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil && caught.Level < *d.MinLevel {
+			return fmt.Sprintf("needs Lv %d, is Lv %d", *d.MinLevel, caught.Level)
+		}
+		if d.TimeOfDay != "" && timeOfDay(now) != d.TimeOfDay {
+			return "needs to level up at " + d.TimeOfDay
+		}
+	case "use-item":
+		if item != d.Item.Name {
+			return "needs --item " + d.Item.Name
+		}
+	default:
+		return describeEvolution(d)
+	}
+	stripped := d
+	stripped.Trigger, stripped.MinLevel, stripped.Item, stripped.TimeOfDay = NamedResource{}, nil, nil, ""
+	if rest := strings.TrimSpace(describeEvolution(stripped)); rest != "" {
+		return "only evolves " + rest
+	}
+	return ""
+}
+
+// timeOfDay returns "day" or "night" using the games' clock, where night runs
+// from 20:00 to 04:00.
+func timeOfDay(t time.Time) string {
+	if t.Hour() >= 20 || t.Hour() < 4 {
+		return "night"
+	}
+	return "day"
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvolutionBlocker(t *testing.T) {
+	var chain EvolutionChain
+	err := decodeJSON(strings.NewReader(eeveeChain), &chain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var bulbasaur EvolutionChain
+	err = decodeJSON(strings.NewReader(bulbasaurChain), &bulbasaur)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ivysaur := bulbasaur.Chain.EvolvesTo[0].EvolutionDetails
+	vaporeon := chain.Chain.EvolvesTo[0].EvolutionDetails
+	espeon := chain.Chain.EvolvesTo[1].EvolutionDetails
+	noon := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		details  []EvolutionDetail
+		level    int
+		item     string
+		expected string
+	}{
+		{"under level", ivysaur, 15, "", "needs Lv 16, is Lv 15"},
+		{"at level", ivysaur, 16, "", ""},
+		{"no item", vaporeon, 30, "", "needs --item water-stone"},
+		{"wrong item", vaporeon, 30, "fire-stone", "needs --item water-stone"},
+		{"right item", vaporeon, 30, "water-stone", ""},
+		{"friendship", espeon, 30, "", "only evolves with friendship 160+"},
+	}
+	for _, c := range cases {
+		caught := &CaughtPokemon{Pokemon: &Pokemon{Name: "test"}, Level: c.level}
+		actual := evolutionBlocker(c.details, caught, c.item, noon)
+		if actual != c.expected {
+			t.Errorf("%s: got %q, expected %q", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestEvolveInto(t *testing.T) {
+	caughtAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	caught := &CaughtPokemon{
+		Pokemon:      &Pokemon{Name: "bulbasaur"},
+		Nickname:     "Bulby",
+		CaughtAt:     caughtAt,
+		Level:        16,
+		History:      []string{"2026-10-01: caught at Lv 5 in route-1-area"},
+		StatsHistory: []StatSnapshot{{Species: "bulbasaur", Level: 5}},
+	}
+	evolveInto(caught, &Pokemon{Name: "ivysaur"}, "", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC))
+
+	if caught.Name != "ivysaur" || caught.Nickname != "Bulby" || !caught.CaughtAt.Equal(caughtAt) {
+		t.Errorf("unexpected pokemon after evolving: %+v", caught)
+	}
+	if len(caught.History) != 2 || caught.History[1] != "2026-10-19: evolved from bulbasaur into ivysaur at Lv 16" {
+		t.Errorf("unexpected history %v", caught.History)
+	}
+	if len(caught.StatsHistory) != 2 || caught.StatsHistory[1].Species != "ivysaur" {
+		t.Errorf("unexpected stats history %v", caught.StatsHistory)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// exploreView is the part of a location-area that explore needs. Decoding into
+// it skips the names and encounter conditions that LocationArea carries.
+type exploreView struct {
+	Name                 string             `json:"name"`
+	EncounterMethodRates []methodRate       `json:"encounter_method_rates"`
+	PokemonEncounters    []exploreEncounter `json:"pokemon_encounters"`
+}
+
+type methodRate struct {
+	EncounterMethod NamedResource `json:"encounter_method"`
+	VersionDetails  []struct {
+		Rate    int           `json:"rate"`
+		Version NamedResource `json:"version"`
+	} `json:"version_details"`
+}
+
+type exploreEncounter struct {
+	Pokemon        NamedResource `json:"pokemon"`
+	VersionDetails []struct {
+		Version          NamedResource `json:"version"`
+		MaxChance        int           `json:"max_chance"`
+		EncounterDetails []struct {
+			MinLevel int           `json:"min_level"`
+			MaxLevel int           `json:"max_level"`
+			Chance   int           `json:"chance"`
+			Method   NamedResource `json:"method"`
+		} `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+func (v *exploreView) decodeStream(dec *json.Decoder) error {
+	return decodeObject(dec, func(key string) error {
+		switch key {
+		case "name":
+			return dec.Decode(&v.Name)
+		case "encounter_method_rates":
+			return dec.Decode(&v.EncounterMethodRates)
+		case "pokemon_encounters":
+			return decodeArray(dec, func() error {
+				var enc exploreEncounter
+				err := dec.Decode(&enc)
+				v.PokemonEncounters = append(v.PokemonEncounters, enc)
+				return err
+			})
+		}
+		return skipValue(dec)
+	})
+}
+
+// encounterSummary is one row of explore's table: a pokemon and a way to
+// encounter it.
+type encounterSummary struct {
+	Name     string
+	ID       int
+	Method   string
+	Chance   int
+	MinLevel int
+	MaxLevel int
+}
+
+func (e encounterSummary) levels() string {
+	if e.MinLevel == e.MaxLevel {
+		return fmt.Sprint(e.MinLevel)
+	}
+	return fmt.Sprintf("%d-%d", e.MinLevel, e.MaxLevel)
+}
+
+func (e encounterSummary) rarity() string {
+	switch {
+	case e.Chance >= 30:
+		return "common"
+	case e.Chance >= 10:
+		return "uncommon"
+	case e.Chance >= 5:
+		return "rare"
+	}
+	return "very rare"
+}
+
+// summarizeEncounters folds each pokemon's encounter details into one summary
+// per encounter method. The chances of every slot for a method are added up
+// per version. When game is set only that version counts and pokemon that
+// can't be found in it are left out; otherwise the best version is used.
+func summarizeEncounters(area exploreView, game string) []encounterSummary {
+	var summaries []encounterSummary
+	for _, enc := range area.PokemonEncounters {
+		first := len(summaries)
+		for _, version := range enc.VersionDetails {
+			if game != "" && version.Version.Name != game {
+				continue
+			}
+			chances := make(map[string]int)
+			for _, detail := range version.EncounterDetails {
+				i := slices.IndexFunc(summaries[first:], func(s encounterSummary) bool {
+					return s.Method == detail.Method.Name
+				})
+				if i == -1 {
+					summaries = append(summaries, encounterSummary{
+						Name:     enc.Pokemon.Name,
+						ID:       resourceID(enc.Pokemon.URL),
+						Method:   detail.Method.Name,
+						MinLevel: detail.MinLevel,
+						MaxLevel: detail.MaxLevel,
+					})
+					i = len(summaries) - first - 1
+				}
+				summary := &summaries[first+i]
+				summary.MinLevel = min(summary.MinLevel, detail.MinLevel)
+				summary.MaxLevel = max(summary.MaxLevel, detail.MaxLevel)
+				chances[detail.Method.Name] += detail.Chance
+				summary.Chance = max(summary.Chance, chances[detail.Method.Name])
+			}
+		}
+	}
+	return summaries
+}
+
+// sortEncounters orders explore's table. rarity puts the hardest to find
+// first, chance the easiest.
+func sortEncounters(encounters []encounterSummary, by string) error {
+	var cmp func(a, b encounterSummary) int
+	switch by {
+	case "":
+		return nil
+	case "rarity":
+		cmp = func(a, b encounterSummary) int { return a.Chance - b.Chance }
+	case "chance":
+		cmp = func(a, b encounterSummary) int { return b.Chance - a.Chance }
+	case "level":
+		cmp = func(a, b encounterSummary) int { return a.MinLevel - b.MinLevel }
+	case "name":
+		cmp = func(a, b encounterSummary) int { return 0 }
+	default:
+		return fmt.Errorf("can't sort by %q, use rarity, chance, level or name", by)
+	}
+	slices.SortStableFunc(encounters, func(a, b encounterSummary) int {
+		if c := cmp(a, b); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return nil
+}
+
+// printEncounters renders explore's table.
+func printEncounters(w io.Writer, encounters []encounterSummary) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "   POKEMON\tMETHOD\tCHANCE\tLEVELS\tRARITY")
+	for _, e := range encounters {
+		fmt.Fprintf(tw, "   %s\t%s\t%d%%\t%s\t%s\n", e.Name, e.Method, e.Chance, e.levels(), e.rarity())
+	}
+	tw.Flush()
+}
+
+// methodRates lists the area's encounter methods available in game with their
+// rate, e.g. "walk 20".
+func methodRates(area exploreView, game string) []string {
+	var rates []string
+	for _, method := range area.EncounterMethodRates {
+		for _, version := range method.VersionDetails {
+			if version.Version.Name == game {
+				rates = append(rates, fmt.Sprintf("%s %d", method.EncounterMethod.Name, version.Rate))
+			}
+		}
+	}
+	return rates
+}
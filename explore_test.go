@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const exploreFixture = `{
+	"name": "viridian-forest-area",
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk"}, "version_details": [
+			{"rate": 8, "version": {"name": "red"}},
+			{"rate": 15, "version": {"name": "firered"}}
+		]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "caterpie"}, "version_details": [
+			{"version": {"name": "red"}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}}
+			]},
+			{"version": {"name": "firered"}, "max_chance": 40, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 4, "max_level": 5, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 10, "max_level": 10, "chance": 10, "method": {"name": "old-rod"}}
+			]}
+		]},
+		{"pokemon": {"name": "pikachu"}, "version_details": [
+			{"version": {"name": "red"}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+			]}
+		]}
+	]
+}`
+
+func TestSummarizeEncounters(t *testing.T) {
+	var area exploreView
+	err := decodeJSON(strings.NewReader(exploreFixture), &area)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		game     string
+		sort     string
+		expected []string
+	}{
+		{"", "", []string{"caterpie walk 40% Lv 3-5", "caterpie old-rod 10% Lv 10", "pikachu walk 5% Lv 3-5"}},
+		{"red", "", []string{"caterpie walk 5% Lv 3", "pikachu walk 5% Lv 3-5"}},
+		{"firered", "", []string{"caterpie walk 40% Lv 3-5", "caterpie old-rod 10% Lv 10"}},
+		{"crystal", "", nil},
+		{"", "rarity", []string{"pikachu walk 5% Lv 3-5", "caterpie old-rod 10% Lv 10", "caterpie walk 40% Lv 3-5"}},
+		{"red", "chance", []string{"caterpie walk 5% Lv 3", "pikachu walk 5% Lv 3-5"}},
+	}
+	for _, c := range cases {
+		encounters := summarizeEncounters(area, c.game)
+		err := sortEncounters(encounters, c.sort)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var actual []string
+		for _, s := range encounters {
+			actual = append(actual, fmt.Sprintf("%s %s %d%% Lv %s", s.Name, s.Method, s.Chance, s.levels()))
+		}
+		if strings.Join(actual, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("game %q sort %q: got %v, expected %v", c.game, c.sort, actual, c.expected)
+		}
+	}
+
+	rates := methodRates(area, "firered")
+	if len(rates) != 1 || rates[0] != "walk 15" {
+		t.Errorf("unexpected method rates %v", rates)
+	}
+	if sortEncounters(nil, "weight") == nil {
+		t.Errorf("expected error sorting by unknown column")
+	}
+}
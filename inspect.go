@@ -0,0 +1,160 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// statBarWidth is how many characters a base stat of maxBaseStat fills.
+const (
+	statBarWidth = 30
+	maxBaseStat  = 255
+)
+
+func commandInspect(config *Config) error {
+	args, flags, err := parseFlags(config.Args, "abilities", "moves", "forms", "history", "all", "no-species")
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which Pokemon? Usage: inspect <id|name>")
+		return nil
+	}
+	val, ok := lookupCaught(args[0])
+	if !ok {
+		return nil
+	}
+	all := flags["all"] != ""
+	if flags["no-species"] == "" {
+		var species PokemonSpecies
+		err := getJSON(val.Species.URL, &species)
+		if err != nil {
+			fmt.Println("Species data unavailable:", err)
+		} else {
+			printSpecies(species, speciesOptions(config, flags))
+		}
+	}
+
+	fmt.Printf("#%03d %s (ID %d)\n", val.Pokemon.ID, val.displayName(), val.ID)
+	fmt.Printf("Level %d, %s nature, caught %s in %s\n", val.Level, val.Nature, val.CaughtAt.Format(time.DateOnly), val.Location)
+	fmt.Println("Exp:", val.Exp)
+	if len(val.KnownMoves) > 0 {
+		fmt.Println("Knows:", strings.Join(val.KnownMoves, ", "))
+	}
+	fmt.Println("Type:", strings.Join(pokemonTypes(*val.Pokemon), "/"))
+	fmt.Println("Height:", formatHeight(val.Height))
+	fmt.Println("Weight:", formatWeight(val.Weight))
+	fmt.Println("Base experience:", val.BaseExperience)
+	fmt.Println("Base stats:")
+	total := 0
+	for _, stats := range val.Stats {
+		name := stats.Stat.Name
+		fmt.Printf("   %-16s %3d %s IV %2d EV %3d = %3d\n", name, stats.BaseStat, statBar(stats.BaseStat), val.IVs[name], val.EVs[name], val.CurrentStats[name])
+		total += stats.BaseStat
+	}
+	fmt.Printf("   %-16s %3d\n", "total (BST)", total)
+
+	if lines := heldItemLines(*val.Pokemon, config.Game); len(lines) > 0 {
+		fmt.Println("Held by wild", val.Name+":")
+		for _, line := range lines {
+			fmt.Println("   -", line)
+		}
+	}
+
+	if all || flags["abilities"] != "" {
+		fmt.Println("Abilities:")
+		for _, ability := range val.Abilities {
+			hidden := ""
+			if ability.IsHidden {
+				hidden = " (hidden)"
+			}
+			fmt.Printf("   %d. %s%s\n", ability.Slot, ability.Ability.Name, hidden)
+		}
+	}
+	if all || flags["forms"] != "" {
+		fmt.Println("Forms:")
+		for _, form := range val.Forms {
+			fmt.Println("   -", form.Name)
+		}
+	}
+	if all || flags["moves"] != "" {
+		printMoves(*val.Pokemon, cmp.Or(config.GameGroup, latestVersionGroup(*val.Pokemon)))
+	}
+	if all || flags["history"] != "" {
+		fmt.Println("History:")
+		for _, event := range val.History {
+			fmt.Println("   -", event)
+		}
+	}
+	return nil
+}
+
+func pokemonTypes(p Pokemon) []string {
+	var types []string
+	for _, typeVal := range p.Types {
+		types = append(types, typeVal.Type.Name)
+	}
+	return types
+}
+
+// printMoves lists what p learns by level up in group, followed by a count of
+// the moves it learns any other way.
+func printMoves(p Pokemon, group string) {
+	other := make(map[string]int)
+	for _, move := range learnset(p, group, "all") {
+		if move.Method != "level-up" {
+			other[move.Method]++
+		}
+	}
+	fmt.Printf("Moves (%s):\n", group)
+	for _, move := range learnset(p, group, "level-up") {
+		fmt.Printf("   Lv %-3d %s\n", move.Level, move.Name)
+	}
+	var counts []string
+	for _, method := range slices.Sorted(maps.Keys(other)) {
+		counts = append(counts, fmt.Sprintf("%d by %s", other[method], method))
+	}
+	if len(counts) > 0 {
+		fmt.Println("   Also learns", strings.Join(counts, ", "))
+	}
+}
+
+// latestVersionGroup returns the newest version group p has moves listed for.
+// Version group ids increase with each release.
+func latestVersionGroup(p Pokemon) string {
+	latest, latestID := "", 0
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			id := resourceID(detail.VersionGroup.URL)
+			if id > latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// formatHeight converts decimetres to metres and feet/inches.
+func formatHeight(dm int) string {
+	inches := int(math.Round(float64(dm) * 3.937008))
+	return fmt.Sprintf("%.1f m (%d'%d\")", float64(dm)/10, inches/12, inches%12)
+}
+
+// formatWeight converts hectograms to kilograms and pounds.
+func formatWeight(hg int) string {
+	return fmt.Sprintf("%.1f kg (%.1f lbs)", float64(hg)/10, float64(hg)*0.2204623)
+}
+
+func statBar(stat int) string {
+	filled := int(math.Round(float64(stat) * statBarWidth / maxBaseStat))
+	if stat > 0 {
+		filled = max(filled, 1)
+	}
+	filled = min(filled, statBarWidth)
+	return strings.Repeat("#", filled) + strings.Repeat(".", statBarWidth-filled)
+}
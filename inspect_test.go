@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestFormatHeightWeight(t *testing.T) {
+	cases := []struct {
+		height, weight int
+		expectedHeight string
+		expectedWeight string
+	}{
+		{4, 60, `0.4 m (1'4")`, "6.0 kg (13.2 lbs)"},
+		{17, 905, `1.7 m (5'7")`, "90.5 kg (199.5 lbs)"},
+		{145, 3300, `14.5 m (47'7")`, "330.0 kg (727.5 lbs)"},
+	}
+	for _, c := range cases {
+		if actual := formatHeight(c.height); actual != c.expectedHeight {
+			t.Errorf("formatHeight(%d) = %s, expected %s", c.height, actual, c.expectedHeight)
+		}
+		if actual := formatWeight(c.weight); actual != c.expectedWeight {
+			t.Errorf("formatWeight(%d) = %s, expected %s", c.weight, actual, c.expectedWeight)
+		}
+	}
+}
+
+func TestStatBar(t *testing.T) {
+	cases := []struct {
+		stat   int
+		filled int
+	}{
+		{0, 0},
+		{1, 1},
+		{35, 4},
+		{255, statBarWidth},
+	}
+	for _, c := range cases {
+		bar := statBar(c.stat)
+		if len(bar) != statBarWidth {
+			t.Errorf("statBar(%d) has width %d", c.stat, len(bar))
+		}
+		filled := 0
+		for _, r := range bar {
+			if r == '#' {
+				filled++
+			}
+		}
+		if filled != c.filled {
+			t.Errorf("statBar(%d) filled %d, expected %d", c.stat, filled, c.filled)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Item struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Cost          int             `json:"cost"`
+	FlingPower    *int            `json:"fling_power"`
+	FlingEffect   *NamedResource  `json:"fling_effect"`
+	Category      NamedResource   `json:"category"`
+	Attributes    []NamedResource `json:"attributes"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+}
+
+func commandItem(config *Config) error {
+	if config.Param == "" {
+		fmt.Println("Which item? Usage: item <name>")
+		return nil
+	}
+	var item Item
+	err := getJSON(pokeAPI+"item/"+config.Param, &item)
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not an item")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(item.Name)
+	fmt.Println("Category:", item.Category.Name)
+	if item.Cost > 0 {
+		fmt.Printf("Cost: %d\n", item.Cost)
+	} else {
+		fmt.Println("Cost: can't be bought")
+	}
+	fling := optionalStat(item.FlingPower)
+	if item.FlingEffect != nil {
+		fling += " (" + item.FlingEffect.Name + ")"
+	}
+	fmt.Println("Fling power:", fling)
+	var attributes []string
+	for _, attribute := range item.Attributes {
+		attributes = append(attributes, attribute.Name)
+	}
+	if len(attributes) > 0 {
+		fmt.Println("Attributes:", strings.Join(attributes, ", "))
+	}
+	for _, entry := range item.EffectEntries {
+		if entry.Language.Name == defaultLang {
+			fmt.Println(strings.Join(strings.Fields(entry.Effect), " "))
+		}
+	}
+	if item.Sprites.Default != "" {
+		fmt.Println("Sprite:", item.Sprites.Default)
+	}
+	return nil
+}
+
+// heldItemLines describes the items p may hold in the wild and how often,
+// grouping the versions that share a rarity. Only game is shown when set.
+func heldItemLines(p Pokemon, game string) []string {
+	var lines []string
+	for _, held := range p.HeldItems {
+		var rarities []int
+		versions := make(map[int][]string)
+		for _, detail := range held.VersionDetails {
+			if game != "" && detail.Version.Name != game {
+				continue
+			}
+			if !slices.Contains(rarities, detail.Rarity) {
+				rarities = append(rarities, detail.Rarity)
+			}
+			versions[detail.Rarity] = append(versions[detail.Rarity], detail.Version.Name)
+		}
+		if len(rarities) == 0 {
+			continue
+		}
+		var parts []string
+		for _, rarity := range rarities {
+			parts = append(parts, fmt.Sprintf("%d%% in %s", rarity, strings.Join(versions[rarity], ", ")))
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", held.Item.Name, strings.Join(parts, "; ")))
+	}
+	return lines
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHeldItemLines(t *testing.T) {
+	const fixture = `{"name": "pikachu", "held_items": [
+		{"item": {"name": "oran-berry"}, "version_details": [
+			{"rarity": 50, "version": {"name": "ruby"}},
+			{"rarity": 50, "version": {"name": "sapphire"}}
+		]},
+		{"item": {"name": "light-ball"}, "version_details": [
+			{"rarity": 5, "version": {"name": "ruby"}},
+			{"rarity": 5, "version": {"name": "sapphire"}},
+			{"rarity": 1, "version": {"name": "black"}}
+		]}
+	]}`
+	var pikachu Pokemon
+	err := json.Unmarshal([]byte(fixture), &pikachu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		game     string
+		expected []string
+	}{
+		{"", []string{"oran-berry: 50% in ruby, sapphire", "light-ball: 5% in ruby, sapphire; 1% in black"}},
+		{"black", []string{"light-ball: 1% in black"}},
+		{"firered", nil},
+	}
+	for _, c := range cases {
+		actual := heldItemLines(pikachu, c.game)
+		if strings.Join(actual, "|") != strings.Join(c.expected, "|") {
+			t.Errorf("game %q: got %v, expected %v", c.game, actual, c.expected)
+		}
+	}
+}
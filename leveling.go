@@ -0,0 +1,162 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	maxLevel = 100
+	// maxEV and maxTotalEVs cap the effort values of one stat and of all
+	// stats together.
+	maxEV       = 252
+	maxTotalEVs = 510
+	// exploreExp is what each party member earns for exploring an area.
+	exploreExp = 50
+)
+
+// GrowthRate is how much experience a species needs to reach each level.
+type GrowthRate struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+// expForLevel is the total experience needed to reach level.
+func expForLevel(rate GrowthRate, level int) int {
+	for _, l := range rate.Levels {
+		if l.Level == level {
+			return l.Experience
+		}
+	}
+	return 0
+}
+
+// levelForExp is the level a Pokemon with exp total experience is at.
+func levelForExp(rate GrowthRate, exp int) int {
+	level := 1
+	for _, l := range rate.Levels {
+		if l.Experience <= exp {
+			level = max(level, l.Level)
+		}
+	}
+	return min(level, maxLevel)
+}
+
+// growthRate fetches the growth rate of p's species.
+func growthRate(p *Pokemon) (GrowthRate, error) {
+	var species PokemonSpecies
+	err := getJSON(p.Species.URL, &species)
+	if err != nil {
+		return GrowthRate{}, err
+	}
+	var rate GrowthRate
+	err = getJSON(species.GrowthRate.URL, &rate)
+	return rate, err
+}
+
+// catchExp is the experience for catching p at level.
+func catchExp(p Pokemon, level int) int {
+	return p.BaseExperience * level / 7
+}
+
+// recalcStats works out c's stats from its species, level, IVs, EVs and
+// nature.
+func (c *CaughtPokemon) recalcStats() {
+	c.CurrentStats = battleStats(*c.Pokemon, c.Level, c.IVs, c.EVs, c.Nature)
+}
+
+// gainEVs adds the effort values p yields to c, up to the limits.
+func gainEVs(c *CaughtPokemon, p Pokemon) {
+	if c.EVs == nil {
+		c.EVs = make(map[string]int)
+	}
+	total := 0
+	for _, ev := range c.EVs {
+		total += ev
+	}
+	for _, stat := range p.Stats {
+		gained := min(stat.Effort, maxEV-c.EVs[stat.Stat.Name], maxTotalEVs-total)
+		if gained > 0 {
+			c.EVs[stat.Stat.Name] += gained
+			total += gained
+		}
+	}
+	c.recalcStats()
+}
+
+// learnMoves teaches c the moves it learns by level up in group from level
+// from to level to, forgetting its oldest move when it already knows
+// maxMoves. It returns what happened for each move learned.
+func learnMoves(c *CaughtPokemon, group string, from, to int) []string {
+	var events []string
+	for _, move := range learnset(*c.Pokemon, group, "level-up") {
+		if move.Level < from || move.Level > to || slices.Contains(c.KnownMoves, move.Name) {
+			continue
+		}
+		if len(c.KnownMoves) < maxMoves {
+			c.KnownMoves = append(c.KnownMoves, move.Name)
+			events = append(events, "learned "+move.Name)
+			continue
+		}
+		events = append(events, fmt.Sprintf("forgot %s and learned %s", c.KnownMoves[0], move.Name))
+		c.KnownMoves = append(c.KnownMoves[1:], move.Name)
+	}
+	return events
+}
+
+// gainExp gives c exp experience, leveling it up as far as it goes. Each new
+// level recalculates its stats, teaches it new moves and is recorded in its
+// history.
+func gainExp(c *CaughtPokemon, exp int, rate GrowthRate, group string, now time.Time) {
+	if c.Level >= maxLevel || exp <= 0 {
+		return
+	}
+	c.Exp = max(c.Exp, expForLevel(rate, c.Level)) + exp
+	fmt.Printf("%s gained %d Exp. Points\n", c.displayName(), exp)
+	level := levelForExp(rate, c.Exp)
+	if level <= c.Level {
+		return
+	}
+	from := c.Level
+	c.Level = level
+	c.recalcStats()
+	fmt.Printf("%s grew to Lv %d!\n", c.displayName(), level)
+	for _, event := range learnMoves(c, group, from+1, level) {
+		fmt.Printf("%s %s!\n", c.displayName(), event)
+	}
+	c.History = append(c.History, fmt.Sprintf("%s: grew to Lv %d", now.Format(time.DateOnly), level))
+	c.StatsHistory = append(c.StatsHistory, snapshotStats(c, now))
+}
+
+// rewardParty gives every party member but the one with ID except exp
+// experience, and the effort values of defeated when it isn't nil.
+func rewardParty(config *Config, exp int, defeated *Pokemon, except int) error {
+	now := time.Now()
+	for _, id := range pokedex.party {
+		member := pokedex.capturedPokemon[id]
+		if id == except {
+			continue
+		}
+		rate, err := growthRate(member.Pokemon)
+		if err != nil {
+			return err
+		}
+		if defeated != nil {
+			gainEVs(member, *defeated)
+		}
+		gainExp(member, exp, rate, cmp.Or(config.GameGroup, latestVersionGroup(*member.Pokemon)), now)
+	}
+	return nil
+}
+
+func snapshotStats(c *CaughtPokemon, at time.Time) StatSnapshot {
+	return StatSnapshot{Species: c.Name, Level: c.Level, At: at, Stats: maps.Clone(c.CurrentStats)}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// mediumFast is the start of the medium-fast growth rate, where a Pokemon at
+// level n has n^3 experience.
+const mediumFast = `{"name": "medium", "levels": [
+	{"level": 1, "experience": 0},
+	{"level": 2, "experience": 8},
+	{"level": 3, "experience": 27},
+	{"level": 4, "experience": 64},
+	{"level": 5, "experience": 125},
+	{"level": 6, "experience": 216},
+	{"level": 7, "experience": 343},
+	{"level": 8, "experience": 512}
+]}`
+
+const bulbasaurLearnset = `{"name": "bulbasaur", "moves": [
+	{"move": {"name": "tackle"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+	{"move": {"name": "growl"}, "version_group_details": [{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+	{"move": {"name": "leech-seed"}, "version_group_details": [{"level_learned_at": 3, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+	{"move": {"name": "vine-whip"}, "version_group_details": [{"level_learned_at": 6, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+	{"move": {"name": "poison-powder"}, "version_group_details": [{"level_learned_at": 7, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue"}}]},
+	{"move": {"name": "cut"}, "version_group_details": [{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue"}}]}
+], "stats": [
+	{"base_stat": 45, "effort": 0, "stat": {"name": "hp"}},
+	{"base_stat": 65, "effort": 1, "stat": {"name": "special-attack"}}
+]}`
+
+func decodeFixture[T any](t *testing.T, fixture string) T {
+	t.Helper()
+	var v T
+	err := json.Unmarshal([]byte(fixture), &v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestLevelForExp(t *testing.T) {
+	rate := decodeFixture[GrowthRate](t, mediumFast)
+	cases := map[int]int{0: 1, 7: 1, 8: 2, 124: 4, 125: 5, 600: 8}
+	for exp, expected := range cases {
+		if actual := levelForExp(rate, exp); actual != expected {
+			t.Errorf("%d exp: got Lv %d, expected Lv %d", exp, actual, expected)
+		}
+	}
+	if exp := expForLevel(rate, 6); exp != 216 {
+		t.Errorf("Lv 6: got %d exp, expected 216", exp)
+	}
+}
+
+func TestGainExp(t *testing.T) {
+	resetPokedex()
+	bulbasaur := decodeFixture[Pokemon](t, bulbasaurLearnset)
+	caught := addCaught(&bulbasaur, 5, "route-1-area", time.Now())
+	learnMoves(caught, "red-blue", 0, caught.Level)
+	if !slices.Equal(caught.KnownMoves, []string{"growl", "tackle", "leech-seed"}) {
+		t.Fatalf("unexpected moves at Lv 5: %v", caught.KnownMoves)
+	}
+	hp := caught.CurrentStats["hp"]
+
+	rate := decodeFixture[GrowthRate](t, mediumFast)
+	gainExp(caught, 50, rate, "red-blue", time.Now())
+	if caught.Level != 5 || caught.Exp != 175 {
+		t.Errorf("expected Lv 5 with 175 exp, got Lv %d with %d", caught.Level, caught.Exp)
+	}
+	gainExp(caught, 200, rate, "red-blue", time.Now())
+	if caught.Level != 7 {
+		t.Errorf("expected Lv 7, got Lv %d", caught.Level)
+	}
+	if !slices.Equal(caught.KnownMoves, []string{"tackle", "leech-seed", "vine-whip", "poison-powder"}) {
+		t.Errorf("unexpected moves at Lv 7: %v", caught.KnownMoves)
+	}
+	if caught.CurrentStats["hp"] <= hp {
+		t.Errorf("expected HP to go up from %d, got %d", hp, caught.CurrentStats["hp"])
+	}
+	if len(caught.StatsHistory) != 2 || caught.StatsHistory[1].Level != 7 {
+		t.Errorf("unexpected stats history %v", caught.StatsHistory)
+	}
+}
+
+func TestGainEVs(t *testing.T) {
+	bulbasaur := decodeFixture[Pokemon](t, bulbasaurLearnset)
+	caught := &CaughtPokemon{Pokemon: &bulbasaur, Level: 50, EVs: map[string]int{"special-attack": maxEV, "hp": 10}}
+	gainEVs(caught, bulbasaur)
+	if caught.EVs["special-attack"] != maxEV {
+		t.Errorf("expected special attack EVs to stay at %d, got %d", maxEV, caught.EVs["special-attack"])
+	}
+
+	caught.EVs = map[string]int{"hp": maxEV, "attack": maxEV, "special-attack": maxTotalEVs - 2*maxEV - 1}
+	gainEVs(caught, bulbasaur)
+	gainEVs(caught, bulbasaur)
+	if caught.EVs["special-attack"] != maxTotalEVs-2*maxEV {
+		t.Errorf("expected EVs to stop at %d in total, got %v", maxTotalEVs, caught.EVs)
+	}
+}
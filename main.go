@@ -2,15 +2,16 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"cmp"
 	"fmt"
 	"github.com/David-Bosnic/pokedexcli/internal"
-	"io"
 	"log"
-	"math/rand"
-	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 type cliCommand struct {
@@ -20,9 +21,19 @@ type cliCommand struct {
 }
 
 type Config struct {
-	Next  string
-	Prev  string
-	Param string
+	Param      string
+	Args       []string
+	RawArgs    []string
+	Game       string
+	GameGroup  string
+	Area       string
+	Encounters []encounterSummary
+	Wild       map[string]*wildPokemon
+	MapShown   bool
+	MapOffset  int
+	MapLimit   int
+	MapCount   int
+	input      *bufio.Scanner
 }
 
 type Pokemon struct {
@@ -309,8 +320,15 @@ type Pokemon struct {
 	} `json:"past_abilities"`
 }
 
+// Pokedex keeps every Pokemon the player owns in capturedPokemon, keyed by
+// their ID, apart from the species they have caught and seen.
 type Pokedex struct {
-	capturedPokemon map[string]Pokemon
+	capturedPokemon map[int]*CaughtPokemon
+	caughtSpecies   map[string]*Pokemon
+	seenPokemon     map[string]SeenPokemon
+	nextID          int
+	party           []int
+	boxes           [][]int
 }
 
 type PokeMap struct {
@@ -379,10 +397,19 @@ type LocationArea struct {
 var commands map[string]cliCommand
 var pokeCache *internal.Cache
 var pokedex Pokedex
+var pokeAPI = "https://pokeapi.co/api/v2/"
+
+const defaultMapLimit = 20
+
+// defaultCatchLevel is the level of a pokemon caught outside an explored area.
+const defaultCatchLevel = 5
 
 func init() {
-	pokedex.capturedPokemon = make(map[string]Pokemon)
-	pokeCache = internal.NewCache(5)
+	pokedex.capturedPokemon = make(map[int]*CaughtPokemon)
+	pokedex.caughtSpecies = make(map[string]*Pokemon)
+	pokedex.boxes = make([][]int, pcBoxCount)
+	pokedex.seenPokemon = make(map[string]SeenPokemon)
+	pokeCache = internal.NewCache(5 * time.Minute)
 	commands = map[string]cliCommand{
 		"exit": {
 			name:        "exit",
@@ -396,17 +423,37 @@ func init() {
 		},
 		"map": {
 			name:        "map",
-			description: "Displays the next 20 locations in the Pokemon world",
+			description: "Displays the next page of locations. Usage: map [next|first|last|page <n>] [--limit <n>]",
 			callback:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
-			description: "Displays the previous 20 locations in the Pokemon world",
+			description: "Displays the previous page of locations. Usage: mapb [--limit <n>]",
 			callback:    commandMapB,
 		},
+		"regions": {
+			name:        "regions",
+			description: "List the regions of the Pokemon world",
+			callback:    commandRegions,
+		},
+		"locations": {
+			name:        "locations",
+			description: "List the locations in a region. Usage: locations <region>",
+			callback:    commandLocations,
+		},
+		"areas": {
+			name:        "areas",
+			description: "List the explorable areas of a location. Usage: areas <location>",
+			callback:    commandAreas,
+		},
+		"set": {
+			name:        "set",
+			description: "Change a session setting. Usage: set game <version|all>",
+			callback:    commandSet,
+		},
 		"explore": {
 			name:        "explore",
-			description: "Explore a location for Pokemon",
+			description: "Explore a location for Pokemon. Usage: explore <area> [--sort rarity|chance|level|name]",
 			callback:    commandExplore,
 		},
 		"catch": {
@@ -416,29 +463,142 @@ func init() {
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "inspect a Pokemon in your Pokedex",
+			description: "inspect a Pokemon in your Pokedex. Usage: inspect <id|name> [--abilities] [--moves] [--forms] [--history] [--all] [--lang <code>] [--version <game>] [--no-species]",
 			callback:    commandInspect,
 		},
+		"dex": {
+			name:        "dex",
+			description: "Show the Pokedex entry of a Pokemon you have seen. Usage: dex <name|id> [--lang <code>] [--version <game>]",
+			callback:    commandDex,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show how a Pokemon evolves. Usage: evolutions <name>",
+			callback:    commandEvolutions,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Evolve a caught Pokemon that meets the conditions. Usage: evolve <id|name> [into] [--item <item>]",
+			callback:    commandEvolve,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Name a caught Pokemon, or clear its nickname. Usage: nickname <id|name> [\"nickname\"]",
+			callback:    commandNickname,
+		},
+		"party": {
+			name:        "party",
+			description: "Show the Pokemon in your party",
+			callback:    commandParty,
+		},
+		"box": {
+			name:        "box",
+			description: "Show the Pokemon in a PC box. Usage: box [n]",
+			callback:    commandBox,
+		},
+		"deposit": {
+			name:        "deposit",
+			description: "Move a party Pokemon to the PC. Usage: deposit <id|name> [box]",
+			callback:    commandDeposit,
+		},
+		"withdraw": {
+			name:        "withdraw",
+			description: "Move a Pokemon from the PC to your party. Usage: withdraw <id|name>",
+			callback:    commandWithdraw,
+		},
+		"swap": {
+			name:        "swap",
+			description: "Swap the places of two Pokemon. Usage: swap <id|name> <id|name>",
+			callback:    commandSwap,
+		},
+		"release": {
+			name:        "release",
+			description: "Release a Pokemon for good. Usage: release <id|name>",
+			callback:    commandRelease,
+		},
+		"save": {
+			name:        "save",
+			description: "Save your Pokedex, party and PC",
+			callback:    commandSave,
+		},
+		"progress": {
+			name:        "progress",
+			description: "Show Pokedex completion per generation and regional dex. Usage: progress [dex]",
+			callback:    commandProgress,
+		},
+		"types": {
+			name:        "types",
+			description: "Show weaknesses, resistances and immunities of a type or type pair. Usage: types <type> [type2]",
+			callback:    commandTypes,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Show type effectiveness between two Pokemon. Usage: matchup <attacker> <defender>",
+			callback:    commandMatchup,
+		},
+		"move": {
+			name:        "move",
+			description: "Look up a move and which of your Pokemon can learn it. Usage: move <name>",
+			callback:    commandMove,
+		},
+		"moves": {
+			name:        "moves",
+			description: "List a Pokemon's learnset. Usage: moves <pokemon> [--version-group <group>] [--method <method>|all]",
+			callback:    commandMoves,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Look up an ability and the Pokemon that have it. Usage: ability <name> [--caught]",
+			callback:    commandAbility,
+		},
+		"item": {
+			name:        "item",
+			description: "Look up an item. Usage: item <name>",
+			callback:    commandItem,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Battle one of your Pokemon against a wild or caught one. Usage: battle <id|name> [<id|name>|wild <name>] [--seed <n>] [--auto]",
+			callback:    commandBattle,
+		},
+		"weaken": {
+			name:        "weaken",
+			description: "Weaken a wild Pokemon before catching it. Usage: weaken <name> [attack|sleep|paralyze]",
+			callback:    commandWeaken,
+		},
+		"team": {
+			name:        "team",
+			description: "Check a team's shared weaknesses, coverage and stats, defaulting to your party. Usage: team [id|name...]",
+			callback:    commandTeam,
+		},
+		"compare": {
+			name:        "compare",
+			description: "Compare Pokemon side by side, caught or not. Usage: compare <id|name> <id|name> [id|name...]",
+			callback:    commandCompare,
+		},
 		"pokedex": {
 			name:        "pokedex",
-			description: "show a list of all your pokemon that you caught",
+			description: "show a list of all your pokemon that you caught. Usage: pokedex [search] [--sort id|name|caught|bst] [--type <type>] [--gen <n>] [--min-bst <n>]",
 			callback:    commandPokedex,
 		},
 	}
 }
 func main() {
 	currentConfig := Config{
-		Next:  "",
-		Prev:  "",
-		Param: "",
+		MapLimit: defaultMapLimit,
 	}
 	scanner := bufio.NewScanner(os.Stdin)
+	currentConfig.input = scanner
 	fmt.Println("Welcome to the Pokedex!")
+	err := loadGame(saveFile)
+	if err != nil {
+		fmt.Println("Could not load your save:", err)
+	}
 	for {
 		fmt.Print("Pokedex > ")
 		scanner.Scan()
 
-		err := scanner.Err()
+		err = scanner.Err()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -446,10 +606,16 @@ func main() {
 		if len(cleanText) != 0 {
 			_, ok := commands[cleanText[0]]
 			if ok {
+				currentConfig.Args = cleanText[1:]
+				currentConfig.RawArgs = splitInput(scanner.Text())[1:]
+				currentConfig.Param = ""
 				if len(cleanText) >= 2 {
 					currentConfig.Param = cleanText[1]
 				}
-				commands[cleanText[0]].callback(&currentConfig)
+				err := commands[cleanText[0]].callback(&currentConfig)
+				if err != nil {
+					fmt.Println("Error:", err)
+				}
 			} else {
 				fmt.Println("Unknown Command")
 			}
@@ -460,11 +626,85 @@ func main() {
 
 func cleanInput(text string) []string {
 	text = strings.ToLower(text)
-	stringers := strings.Fields(text)
+	stringers := splitInput(text)
 	return stringers
 }
 
+// splitInput splits text on whitespace, keeping anything in double quotes
+// together as one word.
+func splitInput(text string) []string {
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inWord = true
+		case unicode.IsSpace(r) && !quoted:
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+			}
+			inWord = false
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// prompt asks the player a question and returns their answer, or false if
+// there is nobody to ask.
+func prompt(config *Config, question string) (string, bool) {
+	if config.input == nil {
+		return "", false
+	}
+	fmt.Print(question)
+	if !config.input.Scan() {
+		return "", false
+	}
+	return strings.TrimSpace(config.input.Text()), true
+}
+
+// parseFlags splits args into positional arguments and --flags. A flag takes
+// the next argument as its value (or --flag=value) unless it is one of
+// boolFlags, in which case its value is "true".
+func parseFlags(args []string, boolFlags ...string) ([]string, map[string]string, error) {
+	var positional []string
+	flags := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok {
+			positional = append(positional, args[i])
+			continue
+		}
+		name, value, hasValue := strings.Cut(name, "=")
+		switch {
+		case hasValue:
+			flags[name] = value
+		case slices.Contains(boolFlags, name):
+			flags[name] = "true"
+		case i+1 < len(args):
+			i++
+			flags[name] = args[i]
+		default:
+			return nil, nil, fmt.Errorf("--%s needs a value", name)
+		}
+	}
+	return positional, flags, nil
+}
+
 func commandExit(config *Config) error {
+	cancelPrefetch()
+	err := autosave(saveFile)
+	if err != nil {
+		fmt.Println("Could not save:", err)
+	}
 	fmt.Println("Closing the Pokedex... Goodbye!")
 	os.Exit(0)
 	return nil
@@ -477,124 +717,175 @@ func commandHelp(config *Config) error {
 	return nil
 }
 func commandMap(config *Config) error {
-	var url string
-	var pokeMap PokeMap
-	if config.Next != "" {
-		url = config.Next
-	} else {
-		url = "https://pokeapi.co/api/v2/location-area/"
+	cancelPrefetch()
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
 	}
-	val, ok := pokeCache.Get(url)
-	if !ok {
-		res, err := http.Get(url)
-		if err != nil {
-			return err
+	limit, err := mapLimitFlag(config, flags)
+	if err != nil {
+		return err
+	}
+	offset := config.MapOffset
+	switch {
+	case (len(args) == 0 || args[0] == "next") && !config.MapShown:
+		offset = 0
+	case len(args) == 0 && flags["limit"] != "":
+		offset = config.MapOffset / limit * limit
+	case len(args) == 0 || args[0] == "next":
+		if config.MapOffset+config.MapLimit >= config.MapCount {
+			fmt.Println("You're on the last page, there is no next page")
+			return nil
 		}
-		body, err := io.ReadAll(res.Body)
-		res.Body.Close()
-		if res.StatusCode > 200 {
-			log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, body)
+		// With a new --limit, show the page of that size holding the first
+		// area after the ones shown.
+		offset = (config.MapOffset + config.MapLimit) / limit * limit
+	case args[0] == "first":
+		offset = 0
+	case args[0] == "last":
+		count := config.MapCount
+		if !config.MapShown {
+			var pokeMap PokeMap
+			err := getJSON(pokeAPI+"location-area/?limit=1", &pokeMap)
+			if err != nil {
+				return err
+			}
+			count = pokeMap.Count
 		}
-		if err != nil {
-			return err
+		_, pages := pageOf(0, limit, count)
+		offset = (pages - 1) * limit
+	case args[0] == "page":
+		if len(args) < 2 {
+			fmt.Println("Which page? Usage: map page <n>")
+			return nil
 		}
-		pokeCache.Add(url, body)
-		err = json.Unmarshal(body, &pokeMap)
-		if err != nil {
-			return err
+		page, err := strconv.Atoi(args[1])
+		if err != nil || page < 1 {
+			fmt.Printf("%q is not a page number\n", args[1])
+			return nil
 		}
-	} else {
-		err := json.Unmarshal(val, &pokeMap)
-		if err != nil {
-			return err
+		if config.MapShown {
+			_, pages := pageOf(0, limit, config.MapCount)
+			if page > pages {
+				fmt.Printf("There are only %d pages\n", pages)
+				return nil
+			}
 		}
+		offset = (page - 1) * limit
+	default:
+		fmt.Println("Usage: map [next|first|last|page <n>] [--limit <n>]")
+		return nil
 	}
-	for _, val := range pokeMap.Results {
-		fmt.Println(val.Name)
-	}
-	config.Next = pokeMap.Next
-	config.Prev = pokeMap.Previous
-	return nil
+	return showMapPage(config, offset, limit)
 }
 func commandMapB(config *Config) error {
-	var url string
+	cancelPrefetch()
+	_, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	limit, err := mapLimitFlag(config, flags)
+	if err != nil {
+		return err
+	}
+	if !config.MapShown || config.MapOffset == 0 {
+		fmt.Println("You're on the first page, there is no previous page")
+		return nil
+	}
+	// Show the page holding the area before the ones shown, which is only
+	// the previous page if the limit didn't change.
+	return showMapPage(config, (config.MapOffset-1)/limit*limit, limit)
+}
+
+// showMapPage prints one page of location areas and remembers where it is so
+// map and mapb can move relative to it.
+func showMapPage(config *Config, offset, limit int) error {
 	var pokeMap PokeMap
-	if config.Prev != "" {
-		url = config.Prev
-	} else {
-		url = "https://pokeapi.co/api/v2/location-area/"
+	url := fmt.Sprintf("%slocation-area/?offset=%d&limit=%d", pokeAPI, offset, limit)
+	err := getJSON(url, &pokeMap)
+	if err != nil {
+		return err
 	}
-	val, ok := pokeCache.Get(url)
-	if !ok {
-		res, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-		body, err := io.ReadAll(res.Body)
-		res.Body.Close()
-		if res.StatusCode > 200 {
-			log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, body)
-		}
-		if err != nil {
-			return err
-		}
-		pokeCache.Add(url, body)
-		err = json.Unmarshal(body, &pokeMap)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := json.Unmarshal(val, &pokeMap)
-		if err != nil {
-			return err
-		}
+	if len(pokeMap.Results) == 0 {
+		fmt.Println("No locations on this page")
+		return nil
 	}
 	for _, val := range pokeMap.Results {
 		fmt.Println(val.Name)
 	}
-	config.Next = pokeMap.Next
-	config.Prev = pokeMap.Previous
+	page, pages := pageOf(offset, limit, pokeMap.Count)
+	fmt.Printf("Page %d of %d\n", page, pages)
+	config.MapShown = true
+	config.MapOffset = offset
+	config.MapLimit = limit
+	config.MapCount = pokeMap.Count
 	return nil
 }
+
+func mapLimitFlag(config *Config, flags map[string]string) (int, error) {
+	if flags["limit"] == "" {
+		return config.MapLimit, nil
+	}
+	limit, err := strconv.Atoi(flags["limit"])
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("--limit must be a positive number, got %q", flags["limit"])
+	}
+	return limit, nil
+}
+
+// pageOf returns the 1-based page that starts at offset and the total number
+// of pages for count results.
+func pageOf(offset, limit, count int) (int, int) {
+	pages := (count + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+	return offset/limit + 1, pages
+}
 func commandExplore(config *Config) error {
-	var exploredLocation LocationArea
-	if config.Param == "" {
+	cancelPrefetch()
+	var exploredLocation exploreView
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
 		fmt.Println("Did not pass location. Add a location ID or name")
 		return nil
 	}
-	fmt.Println("Exploring", config.Param)
-	url := "https://pokeapi.co/api/v2/location-area/" + config.Param
-	val, ok := pokeCache.Get(url)
-	if !ok {
-		res, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-
-		body, err := io.ReadAll(res.Body)
-		res.Body.Close()
-		if res.StatusCode > 200 {
-			log.Fatalf("Response failed with status code: %d and\nbody: %s\n", res.StatusCode, body)
-		}
-		if err != nil {
-			return err
-		}
-		pokeCache.Add(url, body)
-		err = json.Unmarshal(body, &exploredLocation)
-		if err != nil {
-			return err
-		}
-	} else {
-		err := json.Unmarshal(val, &exploredLocation)
-		if err != nil {
-			return err
+	area := args[0]
+	fmt.Println("Exploring", area)
+	err = getJSON(pokeAPI+"location-area/"+area, &exploredLocation)
+	if err != nil {
+		return err
+	}
+	if config.Game != "" {
+		rates := methodRates(exploredLocation, config.Game)
+		if len(rates) == 0 {
+			fmt.Printf("No Pokemon can be found in %s in %s\n", area, config.Game)
+			return nil
 		}
+		fmt.Printf("Encounter rates in %s: %s\n", config.Game, strings.Join(rates, ", "))
 	}
+	encounters := summarizeEncounters(exploredLocation, config.Game)
+	err = sortEncounters(encounters, flags["sort"])
+	if err != nil {
+		return err
+	}
+	config.Area = exploredLocation.Name
+	config.Encounters = encounters
+	config.Wild = make(map[string]*wildPokemon)
 	fmt.Println("Found Pokemon:")
-	for _, val := range exploredLocation.PokemonEncounters {
-		fmt.Println("   -", val.Pokemon.Name)
+	printEncounters(os.Stdout, encounters)
+	var names []string
+	for _, val := range encounters {
+		if !slices.Contains(names, val.Name) {
+			names = append(names, val.Name)
+			markSeen(SeenPokemon{Name: val.Name, ID: val.ID, Area: exploredLocation.Name})
+		}
 	}
-	return nil
+	prefetchPokemon(names)
+	return rewardParty(config, exploreExp, nil, 0)
 }
 func commandCatch(config *Config) error {
 	var pokemon Pokemon
@@ -602,79 +893,115 @@ func commandCatch(config *Config) error {
 		fmt.Println("You can't attempt to catch nothing you silly")
 		return nil
 	}
+	if storageFull() {
+		fmt.Println("Your party and PC are full. Release some Pokemon first")
+		return nil
+	}
 	fmt.Printf("Throwing a Pokeball at %v...\n", config.Param)
-	url := "https://pokeapi.co/api/v2/pokemon/" + config.Param
-	val, ok := pokeCache.Get(url)
-	if !ok {
-		res, err := http.Get(url)
-		if err != nil {
-			return err
-		}
-
-		body, err := io.ReadAll(res.Body)
-		res.Body.Close()
-		if res.StatusCode > 200 {
-			fmt.Println(config.Param, "is not a pokemon or correct id")
-			return err
+	err := getJSON(pokeAPI+"pokemon/"+config.Param, &pokemon)
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not a pokemon or correct id")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	wild := wildState(config, pokemon)
+	if wild.HP == 0 {
+		fmt.Printf("The wild %s has fainted. Explore again to find another\n", pokemon.Name)
+		return nil
+	}
+	var species PokemonSpecies
+	err = getJSON(pokemon.Species.URL, &species)
+	if err != nil {
+		return err
+	}
+	shakes := catchAttempt(wild, species.CaptureRate)
+	markSeen(SeenPokemon{Name: pokemon.Name, ID: pokemon.ID, Types: pokemonTypes(pokemon)})
+	if shakes > 0 {
+		fmt.Printf("The ball shook %d time(s)\n", shakes)
+	}
+	if shakes == 4 {
+		level := wild.Level
+		where := "the wild"
+		if inArea(config, pokemon.Name) {
+			where = config.Area
+			delete(config.Wild, pokemon.Name)
 		}
+		var rate GrowthRate
+		err = getJSON(species.GrowthRate.URL, &rate)
 		if err != nil {
 			return err
 		}
-		pokeCache.Add(url, body)
-		err = json.Unmarshal(body, &pokemon)
-		if err != nil {
-			return err
+		owned := addCaught(&pokemon, level, where, time.Now())
+		stored, _ := storePokemon(owned.ID)
+		learnMoves(owned, cmp.Or(config.GameGroup, latestVersionGroup(pokemon)), 0, level)
+		owned.Exp = expForLevel(rate, level)
+		fmt.Printf("%s was caught at Lv %d! (ID %d)\n", pokemon.Name, level, owned.ID)
+		if stored != "your party" {
+			fmt.Printf("Your party is full, %s was sent to %s\n", pokemon.Name, stored)
 		}
-	} else {
-		err := json.Unmarshal(val, &pokemon)
+		err = rewardParty(config, catchExp(pokemon, level), &pokemon, owned.ID)
 		if err != nil {
 			return err
 		}
-	}
-	caught := catchAttempt(pokemon.BaseExperience)
-	if caught {
-		pokedex.capturedPokemon[pokemon.Name] = pokemon
-		fmt.Println(pokemon.Name, "was caught!")
-	} else {
-		fmt.Println(pokemon.Name, "escaped!")
-	}
-	return nil
-}
-func commandInspect(config *Config) error {
-	val, ok := pokedex.capturedPokemon[config.Param]
-	if ok {
-		fmt.Println("Name:", val.Name)
-		fmt.Println("Height:", val.Height)
-		fmt.Println("Weight:", val.Weight)
-		fmt.Println("Stats:")
-		for _, stats := range val.Stats {
-			fmt.Printf(" - %s: %d\n", stats.Stat.Name, stats.BaseStat)
-		}
-		fmt.Println("Type:")
-		for _, typeVal := range val.Types {
-			fmt.Printf(" - %s\n", typeVal.Type.Name)
+		name, ok := prompt(config, fmt.Sprintf("Give %s a nickname? (enter to skip): ", pokemon.Name))
+		if ok && name != "" {
+			setNickname(owned, strings.Trim(name, `"`))
 		}
 	} else {
-		fmt.Printf("You have not caught a %v or invalid name\n", config.Param)
+		fmt.Println(pokemon.Name, "escaped!")
 	}
 	return nil
 }
 func commandPokedex(config *Config) error {
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Seen: %d  Caught: %d  Owned: %d\n", len(pokedex.seenPokemon), len(pokedex.caughtSpecies), len(pokedex.capturedPokemon))
 	if len(pokedex.capturedPokemon) == 0 {
 		fmt.Println("Go catch some Pokemon! You have none!")
 		return nil
-	} else {
-		fmt.Println("Here is you list of Pokemon:")
-		for name := range pokedex.capturedPokemon {
-			fmt.Printf(" - %s\n", name)
+	}
+	filter, err := newPokedexFilter(strings.Join(args, " "), flags)
+	if err != nil {
+		return err
+	}
+	var listed []*CaughtPokemon
+	for _, caught := range sortedCaught() {
+		if filter.matches(caught) {
+			listed = append(listed, caught)
 		}
 	}
+	err = sortPokedex(listed, flags["sort"])
+	if err != nil {
+		return err
+	}
+	if len(listed) == 0 {
+		fmt.Println("None of your Pokemon match")
+		return nil
+	}
+	fmt.Printf("Here is you list of Pokemon (%d of %d):\n", len(listed), len(pokedex.capturedPokemon))
+	printPokedex(os.Stdout, listed)
 	return nil
 }
-func catchAttempt(exp int) bool {
-	chance := 6000 / exp
-	if chance >= rand.Intn(100) {
-		return true
+
+// catchLevel picks a level for a newly caught pokemon from the levels it was
+// found at in the last explored area, using intn to pick within the range.
+func catchLevel(config *Config, name string, intn func(int) int) int {
+	low, high := 0, 0
+	for _, e := range config.Encounters {
+		if e.Name != name {
+			continue
+		}
+		if low == 0 || e.MinLevel < low {
+			low = e.MinLevel
+		}
+		high = max(high, e.MaxLevel)
+	}
+	if low == 0 {
+		return defaultCatchLevel
 	}
-	return false
+	return low + intn(high-low+1)
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newMapServer(t *testing.T, count int) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var pokeMap PokeMap
+		pokeMap.Count = count
+		for i := offset; i < min(offset+limit, count); i++ {
+			pokeMap.Results = append(pokeMap.Results, NamedResource{Name: fmt.Sprintf("area-%d", i)})
+		}
+		json.NewEncoder(w).Encode(pokeMap)
+	}))
+	t.Cleanup(srv.Close)
+	pokeAPI = srv.URL + "/"
+}
+
+func TestMapPaging(t *testing.T) {
+	newMapServer(t, 45)
+	fresh := &Config{MapLimit: defaultMapLimit, Args: []string{"next"}}
+	err := commandMap(fresh)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !fresh.MapShown || fresh.MapOffset != 0 {
+		t.Errorf("expected map next to show the first page in a new session, got shown %v offset %d", fresh.MapShown, fresh.MapOffset)
+	}
+
+	config := &Config{MapLimit: defaultMapLimit}
+	steps := []struct {
+		command func(*Config) error
+		args    []string
+		offset  int
+		limit   int
+	}{
+		{commandMapB, nil, 0, 20},
+		{commandMap, nil, 0, 20},
+		{commandMap, nil, 20, 20},
+		{commandMap, nil, 40, 20},
+		{commandMap, nil, 40, 20},
+		{commandMapB, nil, 20, 20},
+		{commandMap, []string{"first"}, 0, 20},
+		{commandMap, []string{"last", "--limit", "10"}, 40, 10},
+		{commandMap, []string{"--limit", "15"}, 30, 15},
+		{commandMap, []string{"page", "2"}, 15, 15},
+		{commandMap, []string{"page", "9"}, 15, 15},
+	}
+
+	for i, step := range steps {
+		config.Args = step.args
+		err := step.command(config)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error %v", i, err)
+		}
+		if config.MapOffset != step.offset || config.MapLimit != step.limit {
+			t.Errorf("step %d: got offset %d limit %d, expected offset %d limit %d",
+				i, config.MapOffset, config.MapLimit, step.offset, step.limit)
+		}
+	}
+
+	// With a new --limit, next and mapb land on whole pages of the new size.
+	newMapServer(t, 200)
+	config = &Config{MapLimit: defaultMapLimit}
+	limitSteps := []struct {
+		command func(*Config) error
+		args    []string
+		footer  string
+		offset  int
+	}{
+		{commandMap, nil, "Page 1 of 10", 0},
+		{commandMap, nil, "Page 2 of 10", 20},
+		{commandMap, []string{"next", "--limit", "50"}, "Page 1 of 4", 0},
+		{commandMap, nil, "Page 2 of 4", 50},
+		{commandMapB, []string{"--limit", "30"}, "Page 2 of 7", 30},
+		{commandMap, []string{"next", "--limit", "25"}, "Page 3 of 8", 50},
+		{commandMapB, []string{"--limit", "20"}, "Page 3 of 10", 40},
+	}
+	for i, step := range limitSteps {
+		config.Args = step.args
+		out := captureOutput(t, func() error { return step.command(config) })
+		if !strings.Contains(out, step.footer) || config.MapOffset != step.offset {
+			t.Errorf("step %d: got offset %d and output:\n%s\nexpected offset %d and %q",
+				i, config.MapOffset, out, step.offset, step.footer)
+		}
+	}
+}
+
+func TestPageOf(t *testing.T) {
+	cases := []struct {
+		offset, limit, count int
+		page, pages          int
+	}{
+		{0, 20, 1089, 1, 55},
+		{1080, 20, 1089, 55, 55},
+		{40, 20, 60, 3, 3},
+		{0, 20, 0, 1, 1},
+	}
+	for _, c := range cases {
+		page, pages := pageOf(c.offset, c.limit, c.count)
+		if page != c.page || pages != c.pages {
+			t.Errorf("pageOf(%d, %d, %d) = %d, %d; expected %d, %d",
+				c.offset, c.limit, c.count, page, pages, c.page, c.pages)
+		}
+	}
+}
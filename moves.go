@@ -0,0 +1,157 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+type Move struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Accuracy      *int          `json:"accuracy"`
+	Power         *int          `json:"power"`
+	PP            *int          `json:"pp"`
+	Priority      int           `json:"priority"`
+	EffectChance  *int          `json:"effect_chance"`
+	Type          NamedResource `json:"type"`
+	DamageClass   NamedResource `json:"damage_class"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	LearnedByPokemon []NamedResource `json:"learned_by_pokemon"`
+}
+
+// learnedMove is one entry of a Pokemon's learnset.
+type learnedMove struct {
+	Name   string
+	Method string
+	Level  int
+}
+
+// learnset lists the moves p learns in a version group by method, or by any
+// method when method is "all", ordered by level and then name.
+func learnset(p Pokemon, group, method string) []learnedMove {
+	var moves []learnedMove
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != group {
+				continue
+			}
+			if method != "all" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			moves = append(moves, learnedMove{move.Move.Name, detail.MoveLearnMethod.Name, detail.LevelLearnedAt})
+		}
+	}
+	slices.SortFunc(moves, func(a, b learnedMove) int {
+		return cmp.Or(cmp.Compare(a.Level, b.Level), strings.Compare(a.Name, b.Name), strings.Compare(a.Method, b.Method))
+	})
+	return moves
+}
+
+// moveEffect returns the move's effect text in English with the effect chance
+// filled in.
+func moveEffect(move Move, short bool) string {
+	for _, entry := range move.EffectEntries {
+		if entry.Language.Name != defaultLang {
+			continue
+		}
+		text := entry.Effect
+		if short {
+			text = entry.ShortEffect
+		}
+		if move.EffectChance != nil {
+			text = strings.ReplaceAll(text, "$effect_chance", fmt.Sprint(*move.EffectChance))
+		}
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return ""
+}
+
+func optionalStat(val *int) string {
+	if val == nil {
+		return "-"
+	}
+	return fmt.Sprint(*val)
+}
+
+func commandMove(config *Config) error {
+	if config.Param == "" {
+		fmt.Println("Which move? Usage: move <name>")
+		return nil
+	}
+	var move Move
+	err := getJSON(pokeAPI+"move/"+config.Param, &move)
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not a move")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(move.Name)
+	fmt.Printf("Type: %s  Class: %s  Priority: %+d\n", move.Type.Name, move.DamageClass.Name, move.Priority)
+	fmt.Printf("Power: %s  Accuracy: %s  PP: %s\n", optionalStat(move.Power), optionalStat(move.Accuracy), optionalStat(move.PP))
+	if effect := moveEffect(move, false); effect != "" {
+		fmt.Println(effect)
+	}
+
+	var learners []string
+	for _, caught := range sortedCaught() {
+		if slices.ContainsFunc(move.LearnedByPokemon, func(p NamedResource) bool { return p.Name == caught.Name }) {
+			learners = append(learners, caught.displayName())
+		}
+	}
+	if len(learners) == 0 {
+		fmt.Println("None of your Pokemon can learn it")
+		return nil
+	}
+	fmt.Println("Your Pokemon that can learn it:")
+	for _, name := range learners {
+		fmt.Println("   -", name)
+	}
+	return nil
+}
+
+func commandMoves(config *Config) error {
+	args, flags, err := parseFlags(config.Args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Println("Which Pokemon? Usage: moves <pokemon> [--version-group <group>] [--method level-up|machine|tutor|egg|all]")
+		return nil
+	}
+	pokemon, label, err := resolvePokemon(args[0])
+	if isNotFound(err) {
+		fmt.Println(args[0], "is not a pokemon or correct id")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	group := cmp.Or(flags["version-group"], config.GameGroup, latestVersionGroup(*pokemon))
+	method := cmp.Or(flags["method"], "level-up")
+	moves := learnset(*pokemon, group, method)
+	if len(moves) == 0 {
+		fmt.Printf("%s learns no moves by %s in %s\n", label, method, group)
+		return nil
+	}
+	fmt.Printf("%s learns in %s:\n", label, group)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, move := range moves {
+		level := "-"
+		if move.Method == "level-up" {
+			level = fmt.Sprintf("Lv %d", move.Level)
+		}
+		fmt.Fprintf(tw, "   %s\t%s\t%s\n", level, move.Name, move.Method)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLearnset(t *testing.T) {
+	const fixture = `{"name": "pikachu", "moves": [
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "firered-leafgreen"}},
+			{"level_learned_at": 36, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "scarlet-violet"}}
+		]},
+		{"move": {"name": "thunder-shock"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "firered-leafgreen"}}
+		]},
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "firered-leafgreen"}}
+		]},
+		{"move": {"name": "thunder-wave"}, "version_group_details": [
+			{"level_learned_at": 8, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "firered-leafgreen"}}
+		]}
+	]}`
+	var pikachu Pokemon
+	err := json.Unmarshal([]byte(fixture), &pikachu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		group, method string
+		expected      string
+	}{
+		{"firered-leafgreen", "level-up", "growl thunder-shock thunder-wave"},
+		{"firered-leafgreen", "machine", "thunderbolt"},
+		{"firered-leafgreen", "all", "thunderbolt growl thunder-shock thunder-wave"},
+		{"scarlet-violet", "level-up", "thunderbolt"},
+		{"red-blue", "level-up", ""},
+	}
+	for _, c := range cases {
+		var names []string
+		for _, move := range learnset(pikachu, c.group, c.method) {
+			names = append(names, move.Name)
+		}
+		if strings.Join(names, " ") != c.expected {
+			t.Errorf("learnset(%s, %s) = %v, expected %s", c.group, c.method, names, c.expected)
+		}
+	}
+}
+
+func TestMoveEffect(t *testing.T) {
+	const fixture = `{"name": "thunderbolt", "effect_chance": 10, "effect_entries": [
+		{"effect": "Inflicts regular damage.  Has a $effect_chance% chance to paralyze the target.", "short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}}
+	]}`
+	var move Move
+	err := json.Unmarshal([]byte(fixture), &move)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if effect := moveEffect(move, true); effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected short effect %q", effect)
+	}
+	if effect := moveEffect(move, false); effect != "Inflicts regular damage. Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxNicknameLength matches the games' limit.
+const maxNicknameLength = 12
+
+func commandNickname(config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println(`Which Pokemon? Usage: nickname <id|name> ["nickname"]`)
+		return nil
+	}
+	caught, ok := lookupCaught(config.Args[0])
+	if !ok {
+		return nil
+	}
+	name := ""
+	if len(config.RawArgs) > 1 {
+		name = strings.Join(config.RawArgs[1:], " ")
+	}
+	if name == "" {
+		if caught.Nickname == "" {
+			fmt.Println(caught.Name, "has no nickname")
+			return nil
+		}
+		fmt.Printf("%s is no longer called %s\n", caught.Name, caught.Nickname)
+		caught.Nickname = ""
+		return nil
+	}
+	setNickname(caught, name)
+	return nil
+}
+
+// setNickname names caught, refusing names that are too long or that could
+// be mistaken for an ID or another Pokemon when looking Pokemon up.
+func setNickname(caught *CaughtPokemon, name string) bool {
+	if utf8.RuneCountInString(name) > maxNicknameLength {
+		fmt.Printf("Nicknames can be at most %d characters\n", maxNicknameLength)
+		return false
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		fmt.Println("Nicknames can't be a number")
+		return false
+	}
+	if len(findCaught(strings.ToLower(name))) > 0 && !strings.EqualFold(caught.Nickname, name) {
+		fmt.Printf("%s is already the name or ID of one of your Pokemon\n", name)
+		return false
+	}
+	caught.Nickname = name
+	caught.History = append(caught.History, fmt.Sprintf("%s: nicknamed %s", time.Now().Format(time.DateOnly), name))
+	fmt.Printf("%s is now called %s\n", caught.Name, name)
+	return true
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// generationEnds holds the last national dex number of each generation.
+var generationEnds = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// generationOf returns the generation a national dex number was introduced
+// in, or 0 for numbers past the last known generation such as alternate
+// forms.
+func generationOf(dexID int) int {
+	for i, end := range generationEnds {
+		if dexID <= end {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func baseStatTotal(p *Pokemon) int {
+	total := 0
+	for _, stat := range p.Stats {
+		total += stat.BaseStat
+	}
+	return total
+}
+
+type pokedexFilter struct {
+	search string
+	types  string
+	gen    int
+	minBST int
+}
+
+func newPokedexFilter(search string, flags map[string]string) (pokedexFilter, error) {
+	filter := pokedexFilter{search: search, types: flags["type"]}
+	var err error
+	if flags["gen"] != "" {
+		filter.gen, err = strconv.Atoi(flags["gen"])
+		if err != nil || filter.gen < 1 || filter.gen > len(generationEnds) {
+			return filter, fmt.Errorf("--gen must be 1 to %d, got %q", len(generationEnds), flags["gen"])
+		}
+	}
+	if flags["min-bst"] != "" {
+		filter.minBST, err = strconv.Atoi(flags["min-bst"])
+		if err != nil {
+			return filter, fmt.Errorf("--min-bst must be a number, got %q", flags["min-bst"])
+		}
+	}
+	return filter, nil
+}
+
+func (f pokedexFilter) matches(caught *CaughtPokemon) bool {
+	if f.search != "" && !strings.Contains(caught.Name, f.search) && !strings.Contains(strings.ToLower(caught.Nickname), f.search) {
+		return false
+	}
+	if f.types != "" && !slices.Contains(pokemonTypes(*caught.Pokemon), f.types) {
+		return false
+	}
+	if f.gen != 0 && generationOf(caught.Pokemon.ID) != f.gen {
+		return false
+	}
+	return baseStatTotal(caught.Pokemon) >= f.minBST
+}
+
+// sortPokedex orders the listing. Pokemon are already in catch order, which
+// breaks ties.
+func sortPokedex(listed []*CaughtPokemon, by string) error {
+	var compare func(a, b *CaughtPokemon) int
+	switch by {
+	case "", "id":
+		compare = func(a, b *CaughtPokemon) int { return a.Pokemon.ID - b.Pokemon.ID }
+	case "name":
+		compare = func(a, b *CaughtPokemon) int { return strings.Compare(a.Name, b.Name) }
+	case "caught":
+		compare = func(a, b *CaughtPokemon) int { return a.CaughtAt.Compare(b.CaughtAt) }
+	case "bst":
+		compare = func(a, b *CaughtPokemon) int { return cmp.Compare(baseStatTotal(b.Pokemon), baseStatTotal(a.Pokemon)) }
+	default:
+		return fmt.Errorf("can't sort by %q, use id, name, caught or bst", by)
+	}
+	slices.SortStableFunc(listed, compare)
+	return nil
+}
+
+func printPokedex(w io.Writer, listed []*CaughtPokemon) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, caught := range listed {
+		fmt.Fprintf(tw, " #%03d\t%s\t%s\tLv %d\t%s\tBST %d\tID %d\n",
+			caught.Pokemon.ID, caught.Name, caught.Nickname, caught.Level,
+			strings.Join(pokemonTypes(*caught.Pokemon), "/"), baseStatTotal(caught.Pokemon), caught.ID)
+	}
+	tw.Flush()
+}
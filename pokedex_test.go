@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPokemon(t *testing.T, id int, name string, bst int, types ...string) *Pokemon {
+	t.Helper()
+	var typeList []string
+	for i, typeName := range types {
+		typeList = append(typeList, fmt.Sprintf(`{"slot":%d,"type":{"name":%q}}`, i+1, typeName))
+	}
+	body := fmt.Sprintf(`{"id":%d,"name":%q,"types":[%s],"stats":[{"base_stat":%d,"stat":{"name":"hp"}}]}`,
+		id, name, strings.Join(typeList, ","), bst)
+	var pokemon Pokemon
+	err := json.Unmarshal([]byte(body), &pokemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pokemon
+}
+
+func TestGenerationOf(t *testing.T) {
+	cases := map[int]int{1: 1, 151: 1, 152: 2, 493: 4, 1025: 9, 10001: 0}
+	for id, expected := range cases {
+		if actual := generationOf(id); actual != expected {
+			t.Errorf("generationOf(%d) = %d, expected %d", id, actual, expected)
+		}
+	}
+}
+
+func TestPokedexFilterAndSort(t *testing.T) {
+	resetPokedex()
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var all []*CaughtPokemon
+	for i, p := range []*Pokemon{
+		testPokemon(t, 6, "charizard", 534, "fire", "flying"),
+		testPokemon(t, 4, "charmander", 309, "fire"),
+		testPokemon(t, 155, "cyndaquil", 309, "fire"),
+		testPokemon(t, 25, "pikachu", 320, "electric"),
+	} {
+		all = append(all, addCaught(p, 5, "route-1-area", start.Add(time.Duration(i)*time.Hour)))
+	}
+	all[3].Nickname = "Sparky"
+
+	cases := []struct {
+		search   string
+		flags    map[string]string
+		expected string
+	}{
+		{"", map[string]string{}, "charmander charizard pikachu cyndaquil"},
+		{"", map[string]string{"sort": "name"}, "charizard charmander cyndaquil pikachu"},
+		{"", map[string]string{"sort": "caught"}, "charizard charmander cyndaquil pikachu"},
+		{"", map[string]string{"sort": "bst"}, "charizard pikachu charmander cyndaquil"},
+		{"", map[string]string{"type": "fire", "gen": "1"}, "charmander charizard"},
+		{"", map[string]string{"min-bst": "320"}, "charizard pikachu"},
+		{"char", map[string]string{}, "charmander charizard"},
+		{"spark", map[string]string{}, "pikachu"},
+	}
+	for _, c := range cases {
+		filter, err := newPokedexFilter(c.search, c.flags)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var listed []*CaughtPokemon
+		for _, caught := range all {
+			if filter.matches(caught) {
+				listed = append(listed, caught)
+			}
+		}
+		err = sortPokedex(listed, c.flags["sort"])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var names []string
+		for _, caught := range listed {
+			names = append(names, caught.Name)
+		}
+		if strings.Join(names, " ") != c.expected {
+			t.Errorf("search %q flags %v: got %v, expected %s", c.search, c.flags, names, c.expected)
+		}
+	}
+}
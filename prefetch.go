@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// prefetchWorkers bounds how many pokemon are fetched at the same time.
+const prefetchWorkers = 4
+
+// cancelPrefetch stops the prefetch started by the last explore, if any.
+var cancelPrefetch context.CancelFunc = func() {}
+
+// prefetchPokemon loads each named pokemon into pokeCache in the background so
+// a following catch or inspect doesn't have to wait on the API. A prefetch
+// already in flight is cancelled first. The returned channel is closed once
+// every worker has stopped.
+func prefetchPokemon(names []string) <-chan struct{} {
+	cancelPrefetch()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelPrefetch = cancel
+
+	jobs := make(chan string)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < prefetchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				url := pokeAPI + "pokemon/" + name
+				if _, ok := pokeCache.Get(url); ok {
+					continue
+				}
+				body, err := fetchBody(ctx, url)
+				if err != nil {
+					continue
+				}
+				pokeCache.Add(url, body)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, name := range names {
+			select {
+			case jobs <- name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		cancel()
+		close(done)
+	}()
+	return done
+}
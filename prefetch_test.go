@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPrefetchPokemon(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte(`{"name":"` + strings.TrimPrefix(r.URL.Path, "/pokemon/") + `"}`))
+	}))
+	defer srv.Close()
+	pokeAPI = srv.URL + "/"
+
+	names := []string{"pidgey", "rattata", "spearow", "ekans", "sandshrew", "nidoran-f"}
+	<-prefetchPokemon(names)
+
+	for _, name := range names {
+		val, ok := pokeCache.Get(pokeAPI + "pokemon/" + name)
+		if !ok {
+			t.Errorf("expected %s to be cached", name)
+			continue
+		}
+		if !strings.Contains(string(val), name) {
+			t.Errorf("cached body for %s was %s", name, val)
+		}
+	}
+
+	<-prefetchPokemon(names)
+	if int(hits.Load()) != len(names) {
+		t.Errorf("expected %d requests, got %d", len(names), hits.Load())
+	}
+}
+
+func TestPrefetchCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	pokeAPI = srv.URL + "/"
+
+	done := prefetchPokemon([]string{"mew", "mewtwo", "zapdos", "moltres", "articuno", "dragonite"})
+	cancelPrefetch()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("prefetch did not stop after cancel")
+	}
+	if _, ok := pokeCache.Get(pokeAPI + "pokemon/mew"); ok {
+		t.Errorf("expected cancelled fetch not to be cached")
+	}
+}
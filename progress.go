@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// progressBarWidth is the width of a 100% progress bar.
+const progressBarWidth = 20
+
+var romanNumerals = []string{"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX"}
+
+// RegionalDex is a pokedex from the pokedex endpoint, e.g. kanto or national.
+type RegionalDex struct {
+	ID             int            `json:"id"`
+	Name           string         `json:"name"`
+	IsMainSeries   bool           `json:"is_main_series"`
+	Region         *NamedResource `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int           `json:"entry_number"`
+		PokemonSpecies NamedResource `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+func commandProgress(config *Config) error {
+	caught, seen := speciesProgress()
+	if config.Param != "" {
+		var dex RegionalDex
+		err := getJSON(pokeAPI+"pokedex/"+config.Param, &dex)
+		if isNotFound(err) {
+			fmt.Println(config.Param, "is not a pokedex. Use 'progress' to list them")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		printMissing(dex, caught, seen)
+		return nil
+	}
+
+	fmt.Println("Generations:")
+	start := 1
+	for i, end := range generationEnds {
+		caughtN, seenN := 0, 0
+		for id := start; id <= end; id++ {
+			if caught[id] {
+				caughtN++
+			}
+			if seen[id] {
+				seenN++
+			}
+		}
+		printProgress("Generation "+romanNumerals[i], caughtN, seenN, end-start+1)
+		start = end + 1
+	}
+
+	var list PokeMap
+	err := getJSON(pokeAPI+"pokedex/?limit=100", &list)
+	if err != nil {
+		return err
+	}
+	var urls []string
+	for _, val := range list.Results {
+		urls = append(urls, val.URL)
+	}
+	dexes, err := getAllJSON[RegionalDex](urls)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Regional dexes:")
+	for _, dex := range dexes {
+		if !dex.IsMainSeries {
+			continue
+		}
+		caughtN, seenN, _ := dexProgress(dex, caught, seen)
+		printProgress(dex.Name, caughtN, seenN, len(dex.PokemonEntries))
+	}
+	fmt.Println("Use 'progress <dex>' to see what you're missing")
+	return nil
+}
+
+// speciesProgress returns the national dex numbers of every species caught
+// and seen. Anything caught counts as seen.
+func speciesProgress() (map[int]bool, map[int]bool) {
+	caught := make(map[int]bool)
+	seen := make(map[int]bool)
+	for _, p := range pokedex.caughtSpecies {
+		id := resourceID(p.Species.URL)
+		if id == 0 {
+			id = p.ID
+		}
+		caught[id] = true
+		seen[id] = true
+	}
+	for _, p := range pokedex.seenPokemon {
+		seen[p.ID] = true
+	}
+	return caught, seen
+}
+
+// dexProgress counts the caught and seen species of dex and lists the ones
+// not caught yet, in dex order.
+func dexProgress(dex RegionalDex, caught, seen map[int]bool) (int, int, []string) {
+	caughtN, seenN := 0, 0
+	var missing []string
+	for _, entry := range dex.PokemonEntries {
+		id := resourceID(entry.PokemonSpecies.URL)
+		if seen[id] {
+			seenN++
+		}
+		if caught[id] {
+			caughtN++
+			continue
+		}
+		name := fmt.Sprintf("#%03d %s", entry.EntryNumber, entry.PokemonSpecies.Name)
+		if seen[id] {
+			name += " (seen)"
+		}
+		missing = append(missing, name)
+	}
+	return caughtN, seenN, missing
+}
+
+func printMissing(dex RegionalDex, caught, seen map[int]bool) {
+	caughtN, seenN, missing := dexProgress(dex, caught, seen)
+	printProgress(dex.Name, caughtN, seenN, len(dex.PokemonEntries))
+	if len(missing) == 0 {
+		fmt.Println("You've caught them all!")
+		return
+	}
+	fmt.Printf("Missing %d:\n", len(missing))
+	for _, name := range missing {
+		fmt.Println("   ", name)
+	}
+}
+
+func printProgress(label string, caught, seen, total int) {
+	percent := 0.0
+	if total > 0 {
+		percent = float64(caught) * 100 / float64(total)
+	}
+	filled := int(percent / 100 * progressBarWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", progressBarWidth-filled)
+	fmt.Printf("   %-18s [%s] %4d/%-4d caught %5.1f%%  %d seen\n", label, bar, caught, total, percent, seen)
+}
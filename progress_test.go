@@ -0,0 +1,63 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDexProgress(t *testing.T) {
+	const fixture = `{"name": "kanto", "is_main_series": true, "pokemon_entries": [
+		{"entry_number": 1, "pokemon_species": {"name": "bulbasaur", "url": "https://pokeapi.co/api/v2/pokemon-species/1/"}},
+		{"entry_number": 2, "pokemon_species": {"name": "ivysaur", "url": "https://pokeapi.co/api/v2/pokemon-species/2/"}},
+		{"entry_number": 3, "pokemon_species": {"name": "venusaur", "url": "https://pokeapi.co/api/v2/pokemon-species/3/"}}
+	]}`
+	var dex RegionalDex
+	err := decodeJSON(strings.NewReader(fixture), &dex)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caught := map[int]bool{1: true}
+	seen := map[int]bool{1: true, 2: true}
+
+	caughtN, seenN, missing := dexProgress(dex, caught, seen)
+	if caughtN != 1 || seenN != 2 {
+		t.Errorf("got %d caught %d seen, expected 1 and 2", caughtN, seenN)
+	}
+	if strings.Join(missing, ",") != "#002 ivysaur (seen),#003 venusaur" {
+		t.Errorf("unexpected missing list %v", missing)
+	}
+}
+
+func TestGetAllJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pokedex/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name":"` + strings.TrimPrefix(r.URL.Path, "/pokedex/") + `"}`))
+	}))
+	defer srv.Close()
+	pokeAPI = srv.URL + "/"
+
+	names := []string{"national", "kanto", "original-johto", "hoenn", "original-sinnoh", "extended-sinnoh"}
+	var urls []string
+	for _, name := range names {
+		urls = append(urls, pokeAPI+"pokedex/"+name)
+	}
+	dexes, err := getAllJSON[RegionalDex](urls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, dex := range dexes {
+		if dex.Name != names[i] {
+			t.Errorf("result %d was %s, expected %s", i, dex.Name, names[i])
+		}
+	}
+
+	_, err = getAllJSON[RegionalDex](append(urls, pokeAPI+"pokedex/missing"))
+	if !isNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
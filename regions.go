@@ -0,0 +1,79 @@
+package main
+
+import "fmt"
+
+type Region struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	Locations      []NamedResource `json:"locations"`
+	MainGeneration NamedResource   `json:"main_generation"`
+}
+
+type Location struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region NamedResource   `json:"region"`
+	Areas  []NamedResource `json:"areas"`
+}
+
+func commandRegions(config *Config) error {
+	var regions PokeMap
+	err := getJSON(pokeAPI+"region/?limit=100", &regions)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Regions:")
+	for _, val := range regions.Results {
+		fmt.Println("   -", val.Name)
+	}
+	fmt.Println("Use 'locations <region>' to see the places in a region")
+	return nil
+}
+
+func commandLocations(config *Config) error {
+	var region Region
+	if config.Param == "" {
+		fmt.Println("Which region? Usage: locations <region>")
+		return nil
+	}
+	err := getJSON(pokeAPI+"region/"+config.Param, &region)
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not a region. Use 'regions' to list them")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Locations in %s (%s):\n", region.Name, region.MainGeneration.Name)
+	for _, val := range region.Locations {
+		fmt.Println("   -", val.Name)
+	}
+	fmt.Println("Use 'areas <location>' to see the areas you can explore")
+	return nil
+}
+
+func commandAreas(config *Config) error {
+	var location Location
+	if config.Param == "" {
+		fmt.Println("Which location? Usage: areas <location>")
+		return nil
+	}
+	err := getJSON(pokeAPI+"location/"+config.Param, &location)
+	if isNotFound(err) {
+		fmt.Println(config.Param, "is not a location. Use 'locations <region>' to list them")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(location.Areas) == 0 {
+		fmt.Println(location.Name, "has no areas to explore")
+		return nil
+	}
+	fmt.Printf("Areas in %s (%s):\n", location.Name, location.Region.Name)
+	for _, val := range location.Areas {
+		fmt.Println("   -", val.Name)
+	}
+	fmt.Println("Use 'explore <area>' to look for Pokemon")
+	return nil
+}
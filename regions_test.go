@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// captureOutput returns what run prints to stdout.
+func captureOutput(t *testing.T, run func() error) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	err = run()
+	w.Close()
+	out := <-done
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return out
+}
+
+func TestRegionLocationAreaWalk(t *testing.T) {
+	fixtures := map[string]string{
+		"/region/": `{"count": 2, "results": [{"name": "kanto"}, {"name": "johto"}]}`,
+		"/region/kanto": `{"name": "kanto", "main_generation": {"name": "generation-i"},
+			"locations": [{"name": "pallet-town"}, {"name": "viridian-forest"}]}`,
+		"/location/viridian-forest": `{"name": "viridian-forest", "region": {"name": "kanto"},
+			"areas": [{"name": "viridian-forest-area"}]}`,
+		"/location/pallet-town": `{"name": "pallet-town", "region": {"name": "kanto"}, "areas": []}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	pokeAPI = srv.URL + "/"
+
+	steps := []struct {
+		command  func(*Config) error
+		param    string
+		expected []string
+	}{
+		{commandRegions, "", []string{"   - kanto\n", "   - johto\n"}},
+		{commandLocations, "kanto", []string{"Locations in kanto (generation-i):", "   - viridian-forest\n"}},
+		{commandAreas, "viridian-forest", []string{"Areas in viridian-forest (kanto):", "   - viridian-forest-area\n"}},
+		{commandAreas, "pallet-town", []string{"pallet-town has no areas to explore"}},
+		{commandLocations, "", []string{"Which region?"}},
+		{commandLocations, "orre", []string{"orre is not a region"}},
+		{commandAreas, "", []string{"Which location?"}},
+		{commandAreas, "mt-nowhere", []string{"mt-nowhere is not a location"}},
+	}
+	for _, step := range steps {
+		config := &Config{Param: step.param}
+		out := captureOutput(t, func() error { return step.command(config) })
+		for _, expected := range step.expected {
+			if !strings.Contains(out, expected) {
+				t.Errorf("%q: expected %q in output:\n%s", step.param, expected, out)
+			}
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"maps"
+	"strings"
+	"testing"
+)
 
 func TestCleanInput(t *testing.T) {
 
@@ -38,3 +42,79 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFlags(t *testing.T) {
+	cases := []struct {
+		input      []string
+		positional []string
+		flags      map[string]string
+	}{
+		{
+			input:      []string{"page", "7"},
+			positional: []string{"page", "7"},
+			flags:      map[string]string{},
+		},
+		{
+			input:      []string{"--limit", "50", "next"},
+			positional: []string{"next"},
+			flags:      map[string]string{"limit": "50"},
+		},
+		{
+			input:      []string{"pikachu", "--moves", "--limit=5"},
+			positional: []string{"pikachu"},
+			flags:      map[string]string{"moves": "true", "limit": "5"},
+		},
+	}
+
+	for _, c := range cases {
+		positional, flags, err := parseFlags(c.input, "moves")
+		if err != nil {
+			t.Errorf("Test Failed, unexpected error %v", err)
+			continue
+		}
+		if strings.Join(positional, " ") != strings.Join(c.positional, " ") {
+			t.Errorf("Test Failed, positional %v did not match expected %v", positional, c.positional)
+		}
+		if !maps.Equal(flags, c.flags) {
+			t.Errorf("Test Failed, flags %v did not match expected %v", flags, c.flags)
+		}
+	}
+
+	_, _, err := parseFlags([]string{"--limit"})
+	if err == nil {
+		t.Errorf("Test Failed, expected error for flag without value")
+	}
+}
+
+func TestSplitInput(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    `nickname 3 "Sparky"`,
+			expected: []string{"nickname", "3", "Sparky"},
+		},
+		{
+			input:    `nickname pikachu "Mr Volts"  `,
+			expected: []string{"nickname", "pikachu", "Mr Volts"},
+		},
+		{
+			input:    `nickname 3 ""`,
+			expected: []string{"nickname", "3", ""},
+		},
+	}
+
+	for _, c := range cases {
+		actual := splitInput(c.input)
+		if len(actual) != len(c.expected) {
+			t.Errorf("Test Failed, %v did not match expected %v", actual, c.expected)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("Test Failed, actual %s did not match expected %s", actual[i], c.expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// saveFile is where the game is saved, in the home directory when there is
+// one.
+var saveFile = defaultSaveFile()
+
+// loadFailed is set when the save file exists but couldn't be loaded, so that
+// exiting doesn't write an empty game over it.
+var loadFailed bool
+
+func defaultSaveFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".pokedexcli.json"
+	}
+	return filepath.Join(home, ".pokedexcli.json")
+}
+
+type saveData struct {
+	Species map[string]*Pokemon    `json:"species"`
+	Pokemon []savedPokemon         `json:"pokemon"`
+	Seen    map[string]SeenPokemon `json:"seen"`
+	NextID  int                    `json:"next_id"`
+	Party   []int                  `json:"party"`
+	Boxes   [][]int                `json:"boxes"`
+}
+
+// savedPokemon stores a caught Pokemon with the name of its species in place
+// of the shared species data.
+type savedPokemon struct {
+	Species string `json:"species"`
+	*CaughtPokemon
+}
+
+func commandSave(config *Config) error {
+	err := saveGame(saveFile)
+	if err != nil {
+		return err
+	}
+	loadFailed = false
+	fmt.Println("Saved to", saveFile)
+	return nil
+}
+
+// autosave saves the game on exit, unless the save couldn't be loaded at
+// startup. Saving then would replace it with whatever was played since.
+func autosave(path string) error {
+	if loadFailed {
+		fmt.Printf("Not saving over %s since it couldn't be loaded. Use 'save' to overwrite it\n", path)
+		return nil
+	}
+	return saveGame(path)
+}
+
+func saveGame(path string) error {
+	data := saveData{
+		Species: pokedex.caughtSpecies,
+		Seen:    pokedex.seenPokemon,
+		NextID:  pokedex.nextID,
+		Party:   pokedex.party,
+		Boxes:   pokedex.boxes,
+	}
+	for _, caught := range sortedCaught() {
+		data.Pokemon = append(data.Pokemon, savedPokemon{Species: caught.Name, CaughtPokemon: caught})
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, body, 0o644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadGame replaces the Pokedex with the one saved at path. A missing save
+// file just means a new game.
+func loadGame(path string) error {
+	err := readSave(path)
+	loadFailed = err != nil
+	return err
+}
+
+func readSave(path string) error {
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var data saveData
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return err
+	}
+	captured := make(map[int]*CaughtPokemon)
+	for _, saved := range data.Pokemon {
+		species, ok := data.Species[saved.Species]
+		if !ok {
+			return fmt.Errorf("save is missing species data for %s", saved.Species)
+		}
+		saved.CaughtPokemon.Pokemon = species
+		if saved.CurrentStats == nil {
+			saved.recalcStats()
+		}
+		captured[saved.ID] = saved.CaughtPokemon
+	}
+	if data.Species == nil {
+		data.Species = make(map[string]*Pokemon)
+	}
+	if data.Seen == nil {
+		data.Seen = make(map[string]SeenPokemon)
+	}
+	pokedex.capturedPokemon = captured
+	pokedex.caughtSpecies = data.Species
+	pokedex.seenPokemon = data.Seen
+	pokedex.nextID = data.NextID
+	stored := make(map[int]bool)
+	pokedex.party = storedIDs(data.Party, captured, stored)
+	pokedex.boxes = make([][]int, pcBoxCount)
+	for i := range min(len(data.Boxes), pcBoxCount) {
+		pokedex.boxes[i] = storedIDs(data.Boxes[i], captured, stored)
+	}
+	return nil
+}
+
+// storedIDs drops the IDs of Pokemon the save doesn't have, or that are
+// already stored somewhere else, so a hand edited save can't leave the party
+// or boxes pointing at nothing.
+func storedIDs(ids []int, captured map[int]*CaughtPokemon, stored map[int]bool) []int {
+	var kept []int
+	for _, id := range ids {
+		if captured[id] == nil {
+			fmt.Printf("Dropping Pokemon %d from storage, it isn't in the save\n", id)
+			continue
+		}
+		if stored[id] {
+			fmt.Printf("Dropping Pokemon %d from storage, it is stored twice\n", id)
+			continue
+		}
+		stored[id] = true
+		kept = append(kept, id)
+	}
+	return kept
+}
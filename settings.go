@@ -0,0 +1,44 @@
+package main
+
+import "fmt"
+
+type Version struct {
+	ID           int           `json:"id"`
+	Name         string        `json:"name"`
+	VersionGroup NamedResource `json:"version_group"`
+}
+
+func commandSet(config *Config) error {
+	if len(config.Args) == 0 {
+		game := config.Game
+		if game == "" {
+			game = "all"
+		}
+		fmt.Println("game:", game)
+		return nil
+	}
+	switch config.Args[0] {
+	case "game":
+		if len(config.Args) < 2 || config.Args[1] == "all" {
+			config.Game = ""
+			config.GameGroup = ""
+			fmt.Println("Showing Pokemon from every game")
+			return nil
+		}
+		var version Version
+		err := getJSON(pokeAPI+"version/"+config.Args[1], &version)
+		if isNotFound(err) {
+			fmt.Println(config.Args[1], "is not a game version, try something like firered or crystal")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		config.Game = version.Name
+		config.GameGroup = version.VersionGroup.Name
+		fmt.Printf("Game set to %s (%s)\n", version.Name, version.VersionGroup.Name)
+	default:
+		fmt.Println("Unknown setting", config.Args[0])
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultLang is used for localized text unless --lang picks another.
+const defaultLang = "en"
+
+type PokemonSpecies struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	CaptureRate    int             `json:"capture_rate"`
+	IsBaby         bool            `json:"is_baby"`
+	IsLegendary    bool            `json:"is_legendary"`
+	IsMythical     bool            `json:"is_mythical"`
+	Color          NamedResource   `json:"color"`
+	Shape          *NamedResource  `json:"shape"`
+	Habitat        *NamedResource  `json:"habitat"`
+	Generation     NamedResource   `json:"generation"`
+	GrowthRate     NamedResource   `json:"growth_rate"`
+	EggGroups      []NamedResource `json:"egg_groups"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Names []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+	FlavorTextEntries []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   NamedResource `json:"language"`
+		Version    NamedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Genera []struct {
+		Genus    string        `json:"genus"`
+		Language NamedResource `json:"language"`
+	} `json:"genera"`
+}
+
+// speciesDisplay picks the language and game a species entry is shown in.
+type speciesDisplay struct {
+	lang    string
+	version string
+}
+
+// speciesOptions reads --lang and --version, falling back to the session game
+// for the flavor text.
+func speciesOptions(config *Config, flags map[string]string) speciesDisplay {
+	opts := speciesDisplay{lang: flags["lang"], version: flags["version"]}
+	if opts.lang == "" {
+		opts.lang = defaultLang
+	}
+	if opts.version == "" {
+		opts.version = config.Game
+	}
+	return opts
+}
+
+func printSpecies(species PokemonSpecies, opts speciesDisplay) {
+	name := speciesName(species, opts.lang)
+	if genus := speciesGenus(species, opts.lang); genus != "" {
+		name += " - " + genus
+	}
+	fmt.Println(name)
+	var tags []string
+	if species.IsBaby {
+		tags = append(tags, "baby")
+	}
+	if species.IsLegendary {
+		tags = append(tags, "legendary")
+	}
+	if species.IsMythical {
+		tags = append(tags, "mythical")
+	}
+	if len(tags) > 0 {
+		fmt.Printf("A %s Pokemon\n", strings.Join(tags, ", "))
+	}
+	fmt.Println("Generation:", species.Generation.Name)
+	fmt.Println("Color:", species.Color.Name)
+	if species.Shape != nil {
+		fmt.Println("Shape:", species.Shape.Name)
+	}
+	if species.Habitat != nil {
+		fmt.Println("Habitat:", species.Habitat.Name)
+	}
+	var eggGroups []string
+	for _, group := range species.EggGroups {
+		eggGroups = append(eggGroups, group.Name)
+	}
+	if len(eggGroups) > 0 {
+		fmt.Println("Egg groups:", strings.Join(eggGroups, ", "))
+	}
+	text := flavorText(species, opts.lang, opts.version)
+	switch {
+	case text != "":
+		fmt.Println(text)
+	case opts.version != "":
+		fmt.Printf("No %s entry in %s\n", opts.lang, opts.version)
+	default:
+		fmt.Printf("No %s entry\n", opts.lang)
+	}
+}
+
+// speciesName returns the species' name in lang, or its API name if there is
+// no translation. Languages are matched ignoring case since input is
+// lowercased but codes like ja-Hrkt are not.
+func speciesName(species PokemonSpecies, lang string) string {
+	for _, name := range species.Names {
+		if strings.EqualFold(name.Language.Name, lang) {
+			return name.Name
+		}
+	}
+	return species.Name
+}
+
+func speciesGenus(species PokemonSpecies, lang string) string {
+	for _, genus := range species.Genera {
+		if strings.EqualFold(genus.Language.Name, lang) {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+// flavorText returns the species' dex entry in lang from version, or the most
+// recent one when version is empty. The API keeps the line breaks and form
+// feeds of the original games, so those are flattened to spaces.
+func flavorText(species PokemonSpecies, lang, version string) string {
+	text := ""
+	for _, entry := range species.FlavorTextEntries {
+		if !strings.EqualFold(entry.Language.Name, lang) {
+			continue
+		}
+		if version == "" || entry.Version.Name == version {
+			text = entry.FlavorText
+		}
+	}
+	text = strings.NewReplacer("\u00ad\n", "", "\u00ad", "", "\n", " ", "\f", " ").Replace(text)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlavorText(t *testing.T) {
+	const fixture = `{"id": 25, "name": "pikachu", "flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "Il lui arrive de remettre d'aplomb un Pikachu allié.", "language": {"name": "fr"}, "version": {"name": "x"}},
+		{"flavor_text": "It has small electric sacs on both its cheeks. If threat\u00ad\nened, it looses electric charges from the sacs.", "language": {"name": "en"}, "version": {"name": "ruby"}}
+	]}`
+	var species PokemonSpecies
+	err := decodeJSON(strings.NewReader(fixture), &species)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		lang, version, expected string
+	}{
+		{"en", "", "It has small electric sacs on both its cheeks. If threatened, it looses electric charges from the sacs."},
+		{"en", "red", "When several of these POKéMON gather, their electricity could build and cause lightning storms."},
+		{"fr", "", "Il lui arrive de remettre d'aplomb un Pikachu allié."},
+		{"en", "crystal", ""},
+	}
+	for _, c := range cases {
+		actual := flavorText(species, c.lang, c.version)
+		if actual != c.expected {
+			t.Errorf("flavorText(%s, %s) = %q, expected %q", c.lang, c.version, actual, c.expected)
+		}
+	}
+}
+
+func TestSpeciesName(t *testing.T) {
+	const fixture = `{"name": "pikachu", "names": [
+		{"name": "ピカチュウ", "language": {"name": "ja-Hrkt"}},
+		{"name": "Pikachu", "language": {"name": "en"}}
+	], "genera": [{"genus": "Mouse Pokémon", "language": {"name": "en"}}]}`
+	var species PokemonSpecies
+	err := decodeJSON(strings.NewReader(fixture), &species)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := speciesName(species, "ja-Hrkt"); name != "ピカチュウ" {
+		t.Errorf("expected japanese name, got %s", name)
+	}
+	_, flags, err := parseFlags(cleanInput("dex pikachu --lang ja-Hrkt")[1:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name := speciesName(species, speciesOptions(&Config{}, flags).lang); name != "ピカチュウ" {
+		t.Errorf("expected japanese name from lowercased input, got %s", name)
+	}
+	if name := speciesName(species, "ko"); name != "pikachu" {
+		t.Errorf("expected fallback to api name, got %s", name)
+	}
+	if genus := speciesGenus(species, "en"); genus != "Mouse Pokémon" {
+		t.Errorf("unexpected genus %s", genus)
+	}
+}
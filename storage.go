@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxPartySize = 6
+	pcBoxCount   = 8
+	pcBoxSize    = 30
+)
+
+// storePokemon puts a newly caught Pokemon in the party, or the first PC box
+// with room once the party is full. It returns where it went.
+func storePokemon(id int) (string, bool) {
+	if len(pokedex.party) < maxPartySize {
+		pokedex.party = append(pokedex.party, id)
+		return "your party", true
+	}
+	for i := range pokedex.boxes {
+		if len(pokedex.boxes[i]) < pcBoxSize {
+			pokedex.boxes[i] = append(pokedex.boxes[i], id)
+			return fmt.Sprintf("Box %d", i+1), true
+		}
+	}
+	return "", false
+}
+
+func storageFull() bool {
+	if len(pokedex.party) < maxPartySize {
+		return false
+	}
+	for _, box := range pokedex.boxes {
+		if len(box) < pcBoxSize {
+			return false
+		}
+	}
+	return true
+}
+
+// storageOf returns which box a Pokemon is in, 0 meaning the party, and its
+// slot there.
+func storageOf(id int) (int, int) {
+	if slot := slices.Index(pokedex.party, id); slot != -1 {
+		return 0, slot
+	}
+	for i, box := range pokedex.boxes {
+		if slot := slices.Index(box, id); slot != -1 {
+			return i + 1, slot
+		}
+	}
+	return -1, -1
+}
+
+// storageSlice returns the party for box 0, otherwise the numbered PC box.
+func storageSlice(box int) *[]int {
+	if box == 0 {
+		return &pokedex.party
+	}
+	return &pokedex.boxes[box-1]
+}
+
+// stored reports whether c is in the party or a PC box, telling the player
+// when it is in neither.
+func stored(c *CaughtPokemon) bool {
+	if box, _ := storageOf(c.ID); box == -1 {
+		fmt.Printf("%s is not in your party or PC. Withdraw it first\n", c.displayName())
+		return false
+	}
+	return true
+}
+
+func removeFromStorage(id int) {
+	box, slot := storageOf(id)
+	if box == -1 {
+		return
+	}
+	s := storageSlice(box)
+	*s = slices.Delete(*s, slot, slot+1)
+}
+
+func commandParty(config *Config) error {
+	if len(pokedex.party) == 0 {
+		fmt.Println("Your party is empty. Go catch some Pokemon!")
+		return nil
+	}
+	fmt.Println("Your party:")
+	for i, id := range pokedex.party {
+		caught := pokedex.capturedPokemon[id]
+		fmt.Printf(" %d. %s Lv %d (ID %d)\n", i+1, caught.displayName(), caught.Level, caught.ID)
+	}
+	return nil
+}
+
+func commandBox(config *Config) error {
+	n := 1
+	if config.Param != "" {
+		var err error
+		n, err = strconv.Atoi(config.Param)
+		if err != nil || n < 1 || n > pcBoxCount {
+			fmt.Printf("Pick a box from 1 to %d\n", pcBoxCount)
+			return nil
+		}
+	}
+	var counts []string
+	for i, box := range pokedex.boxes {
+		counts = append(counts, fmt.Sprintf("%d:%d", i+1, len(box)))
+	}
+	fmt.Printf("Box %d (%d/%d)  [%s]\n", n, len(pokedex.boxes[n-1]), pcBoxSize, strings.Join(counts, " "))
+	if len(pokedex.boxes[n-1]) == 0 {
+		fmt.Println("This box is empty")
+		return nil
+	}
+	for _, id := range pokedex.boxes[n-1] {
+		caught := pokedex.capturedPokemon[id]
+		fmt.Printf(" - %d: %s Lv %d\n", caught.ID, caught.displayName(), caught.Level)
+	}
+	return nil
+}
+
+func commandDeposit(config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println("Which Pokemon? Usage: deposit <id|name> [box]")
+		return nil
+	}
+	caught, ok := lookupCaught(config.Args[0])
+	if !ok {
+		return nil
+	}
+	if !stored(caught) {
+		return nil
+	}
+	if box, _ := storageOf(caught.ID); box != 0 {
+		fmt.Println(caught.displayName(), "is not in your party")
+		return nil
+	}
+	if len(pokedex.party) == 1 {
+		fmt.Println("You can't deposit your last party Pokemon")
+		return nil
+	}
+	target := 0
+	if len(config.Args) > 1 {
+		n, err := strconv.Atoi(config.Args[1])
+		if err != nil || n < 1 || n > pcBoxCount {
+			fmt.Printf("Pick a box from 1 to %d\n", pcBoxCount)
+			return nil
+		}
+		target = n
+	} else {
+		for i, box := range pokedex.boxes {
+			if len(box) < pcBoxSize {
+				target = i + 1
+				break
+			}
+		}
+	}
+	if target == 0 || len(pokedex.boxes[target-1]) >= pcBoxSize {
+		fmt.Println("There is no room in the PC")
+		return nil
+	}
+	removeFromStorage(caught.ID)
+	pokedex.boxes[target-1] = append(pokedex.boxes[target-1], caught.ID)
+	fmt.Printf("%s was sent to Box %d\n", caught.displayName(), target)
+	return nil
+}
+
+func commandWithdraw(config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println("Which Pokemon? Usage: withdraw <id|name>")
+		return nil
+	}
+	caught, ok := lookupCaught(config.Args[0])
+	if !ok {
+		return nil
+	}
+	if box, _ := storageOf(caught.ID); box == 0 {
+		fmt.Println(caught.displayName(), "is already in your party")
+		return nil
+	}
+	if len(pokedex.party) >= maxPartySize {
+		fmt.Println("Your party is full. Deposit or swap a Pokemon first")
+		return nil
+	}
+	removeFromStorage(caught.ID)
+	pokedex.party = append(pokedex.party, caught.ID)
+	fmt.Println(caught.displayName(), "joined your party")
+	return nil
+}
+
+func commandSwap(config *Config) error {
+	if len(config.Args) < 2 {
+		fmt.Println("Which Pokemon? Usage: swap <id|name> <id|name>")
+		return nil
+	}
+	a, ok := lookupCaught(config.Args[0])
+	if !ok {
+		return nil
+	}
+	b, ok := lookupCaught(config.Args[1])
+	if !ok {
+		return nil
+	}
+	if !stored(a) || !stored(b) {
+		return nil
+	}
+	boxA, slotA := storageOf(a.ID)
+	boxB, slotB := storageOf(b.ID)
+	(*storageSlice(boxA))[slotA], (*storageSlice(boxB))[slotB] = b.ID, a.ID
+	fmt.Printf("Swapped %s and %s\n", a.displayName(), b.displayName())
+	return nil
+}
+
+func commandRelease(config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println("Which Pokemon? Usage: release <id|name>")
+		return nil
+	}
+	caught, ok := lookupCaught(config.Args[0])
+	if !ok {
+		return nil
+	}
+	if !stored(caught) {
+		return nil
+	}
+	if box, _ := storageOf(caught.ID); box == 0 && len(pokedex.party) == 1 {
+		fmt.Println("You can't release your last party Pokemon")
+		return nil
+	}
+	answer, ok := prompt(config, fmt.Sprintf("Release %s? It can't come back. (y/n): ", caught.displayName()))
+	if ok && !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+		fmt.Println(caught.displayName(), "stays with you")
+		return nil
+	}
+	removeFromStorage(caught.ID)
+	delete(pokedex.capturedPokemon, caught.ID)
+	fmt.Printf("%s was released. Bye bye!\n", caught.displayName())
+	return nil
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func resetPokedex() {
+	pokedex.capturedPokemon = make(map[int]*CaughtPokemon)
+	pokedex.caughtSpecies = make(map[string]*Pokemon)
+	pokedex.seenPokemon = make(map[string]SeenPokemon)
+	pokedex.nextID = 0
+	pokedex.party = nil
+	pokedex.boxes = make([][]int, pcBoxCount)
+}
+
+func catchForTest(name string) *CaughtPokemon {
+	caught := addCaught(&Pokemon{Name: name}, 5, "route-1-area", time.Now())
+	storePokemon(caught.ID)
+	return caught
+}
+
+func TestPartyAndBoxes(t *testing.T) {
+	resetPokedex()
+	for _, name := range []string{"pidgey", "rattata", "spearow", "ekans", "sandshrew", "nidoran-f", "zubat"} {
+		catchForTest(name)
+	}
+	if !slices.Equal(pokedex.party, []int{1, 2, 3, 4, 5, 6}) || !slices.Equal(pokedex.boxes[0], []int{7}) {
+		t.Fatalf("expected 6 in party and 1 in box 1, got %v %v", pokedex.party, pokedex.boxes[0])
+	}
+
+	config := &Config{}
+	steps := []struct {
+		command func(*Config) error
+		args    []string
+		party   []int
+		box1    []int
+		box2    []int
+	}{
+		{commandWithdraw, []string{"zubat"}, []int{1, 2, 3, 4, 5, 6}, []int{7}, nil},
+		{commandDeposit, []string{"2", "2"}, []int{1, 3, 4, 5, 6}, []int{7}, []int{2}},
+		{commandWithdraw, []string{"zubat"}, []int{1, 3, 4, 5, 6, 7}, []int{}, []int{2}},
+		{commandSwap, []string{"1", "rattata"}, []int{2, 3, 4, 5, 6, 7}, []int{}, []int{1}},
+		{commandSwap, []string{"7", "2"}, []int{7, 3, 4, 5, 6, 2}, []int{}, []int{1}},
+		{commandRelease, []string{"pidgey"}, []int{7, 3, 4, 5, 6, 2}, []int{}, []int{}},
+	}
+	for i, step := range steps {
+		config.Args = step.args
+		err := step.command(config)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error %v", i, err)
+		}
+		if !slices.Equal(pokedex.party, step.party) || !slices.Equal(pokedex.boxes[0], step.box1) || !slices.Equal(pokedex.boxes[1], step.box2) {
+			t.Errorf("step %d: got party %v box1 %v box2 %v", i, pokedex.party, pokedex.boxes[0], pokedex.boxes[1])
+		}
+	}
+	if _, ok := pokedex.capturedPokemon[1]; ok {
+		t.Errorf("expected pidgey to be released")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	resetPokedex()
+	pikachu := catchForTest("pikachu")
+	pikachu.Nickname = "Sparky"
+	catchForTest("pikachu")
+	markSeen(SeenPokemon{Name: "pikachu", ID: 25})
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	err := saveGame(path)
+	if err != nil {
+		t.Fatalf("unexpected error saving: %v", err)
+	}
+	resetPokedex()
+	err = loadGame(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+
+	loaded := pokedex.capturedPokemon[pikachu.ID]
+	if loaded == nil || loaded.Nickname != "Sparky" || loaded.Name != "pikachu" || loaded.IVs == nil {
+		t.Fatalf("unexpected pokemon after load: %+v", loaded)
+	}
+	if loaded.Pokemon != pokedex.capturedPokemon[2].Pokemon {
+		t.Errorf("expected loaded pokemon to share species data")
+	}
+	if !slices.Equal(pokedex.party, []int{1, 2}) || pokedex.nextID != 2 || len(pokedex.boxes) != pcBoxCount {
+		t.Errorf("unexpected storage after load: party %v next %d", pokedex.party, pokedex.nextID)
+	}
+	if _, ok := pokedex.seenPokemon["pikachu"]; !ok {
+		t.Errorf("expected seen pokemon to be loaded")
+	}
+
+	err = loadGame(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Errorf("expected a missing save to start a new game, got %v", err)
+	}
+}
+
+func TestLoadDropsUnknownIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	save := `{"species": {"pidgey": {"name": "pidgey"}},
+		"pokemon": [{"species": "pidgey", "ID": 1}, {"species": "pidgey", "ID": 2}],
+		"next_id": 2, "party": [1, 7], "boxes": [[2, 1, 9], [3]]}`
+	err := os.WriteFile(path, []byte(save), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resetPokedex()
+	err = loadGame(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading: %v", err)
+	}
+	if !slices.Equal(pokedex.party, []int{1}) || !slices.Equal(pokedex.boxes[0], []int{2}) || len(pokedex.boxes[1]) != 0 {
+		t.Errorf("expected unknown and repeated IDs to be dropped, got party %v boxes %v", pokedex.party, pokedex.boxes)
+	}
+	for _, command := range []func(*Config) error{commandParty, commandBox} {
+		err := command(&Config{})
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+}
+
+func TestUnstoredPokemon(t *testing.T) {
+	resetPokedex()
+	catchForTest("pidgey")
+	catchForTest("rattata")
+	orphan := addCaught(&Pokemon{Name: "zubat"}, 5, "route-1-area", time.Now())
+
+	config := &Config{}
+	for _, args := range [][]string{{"zubat", "1"}, {"1", "zubat"}} {
+		config.Args = args
+		err := commandSwap(config)
+		if err != nil {
+			t.Fatalf("swap %v: unexpected error %v", args, err)
+		}
+	}
+	config.Args = []string{"zubat"}
+	for _, command := range []func(*Config) error{commandDeposit, commandRelease} {
+		err := command(config)
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if !slices.Equal(pokedex.party, []int{1, 2}) {
+		t.Errorf("expected the party to be untouched, got %v", pokedex.party)
+	}
+	if _, ok := pokedex.capturedPokemon[orphan.ID]; !ok {
+		t.Errorf("expected zubat not to be released")
+	}
+}
+
+func TestFailedLoadKeepsSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	for _, corrupt := range []string{`{"pokemon": [`, `{"pokemon": [{"species": "mew", "ID": 1}]}`} {
+		err := os.WriteFile(path, []byte(corrupt), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resetPokedex()
+		err = loadGame(path)
+		if err == nil {
+			t.Fatalf("expected %s to fail to load", corrupt)
+		}
+		catchForTest("pidgey")
+		err = autosave(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != corrupt {
+			t.Errorf("expected the save to be left alone, got %s", body)
+		}
+	}
+	loadFailed = false
+}
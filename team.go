@@ -0,0 +1,272 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// allTypes are the types a team's weaknesses and coverage are checked
+// against.
+var allTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice", "fighting", "poison", "ground",
+	"flying", "psychic", "bug", "rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// statColumns are the stats shown in team's table and their headings.
+var statColumns = [][2]string{
+	{"hp", "HP"}, {"attack", "Atk"}, {"defense", "Def"},
+	{"special-attack", "SpA"}, {"special-defense", "SpD"}, {"speed", "Spe"},
+}
+
+// maxSuggestions is how many caught Pokemon team suggests adding.
+const maxSuggestions = 3
+
+// teamMember is a caught Pokemon with the types and damaging move types team
+// analyzes.
+type teamMember struct {
+	Caught    *CaughtPokemon
+	Types     []PokemonType
+	MoveTypes []string
+}
+
+// typeWeakness is how many team members are weak to and resist an attacking
+// type.
+type typeWeakness struct {
+	Type   string
+	Weak   int
+	Resist int
+}
+
+func commandTeam(config *Config) error {
+	ids := config.Args
+	if len(ids) == 0 {
+		for _, id := range pokedex.party {
+			ids = append(ids, fmt.Sprint(id))
+		}
+	}
+	if len(ids) == 0 {
+		fmt.Println("Your party is empty. Usage: team [id|name...]")
+		return nil
+	}
+	if len(ids) > maxPartySize {
+		fmt.Printf("A team has at most %d Pokemon\n", maxPartySize)
+		return nil
+	}
+	var team []teamMember
+	for _, id := range ids {
+		caught, ok := lookupCaught(id)
+		if !ok {
+			return nil
+		}
+		member, err := newTeamMember(config, caught)
+		if err != nil {
+			return err
+		}
+		team = append(team, member)
+	}
+	defenders, err := getTypes(allTypes)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, member := range team {
+		names = append(names, member.Caught.displayName())
+	}
+	fmt.Println("Team:", strings.Join(names, ", "))
+	weaknesses := sharedWeaknesses(team)
+	fmt.Println("Shared weaknesses:")
+	if len(weaknesses) == 0 {
+		fmt.Println("   None")
+	}
+	for _, w := range weaknesses {
+		fmt.Printf("   %-10s %d weak, %d resist\n", w.Type, w.Weak, w.Resist)
+	}
+	moveTypes := teamMoveTypes(team)
+	covered, gaps := coverage(moveTypes, defenders)
+	fmt.Println("Offensive coverage from move types:", listOrNone(moveTypes))
+	fmt.Println("   Super effective against:", listOrNone(covered))
+	fmt.Println("   Nothing super effective against:", listOrNone(gaps))
+	fmt.Println("Base stats:")
+	printTeamStats(os.Stdout, team)
+
+	var candidates []teamMember
+	for _, caught := range sortedCaught() {
+		if slices.ContainsFunc(team, func(m teamMember) bool { return m.Caught == caught }) {
+			continue
+		}
+		member, err := newTeamMember(config, caught)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, member)
+	}
+	suggestions := suggestMembers(candidates, gaps, weaknesses, defenders)
+	if len(suggestions) > 0 && len(team) < maxPartySize {
+		fmt.Println("Could help:")
+		for _, s := range suggestions {
+			fmt.Println("   -", s)
+		}
+	}
+	return nil
+}
+
+func newTeamMember(config *Config, caught *CaughtPokemon) (teamMember, error) {
+	b, err := caughtBattler(caught, config.GameGroup)
+	if err != nil {
+		return teamMember{}, err
+	}
+	member := teamMember{Caught: caught, Types: b.Types}
+	for _, move := range b.Moves {
+		if move.Name != struggle.Name && !slices.Contains(member.MoveTypes, move.Type.Name) {
+			member.MoveTypes = append(member.MoveTypes, move.Type.Name)
+		}
+	}
+	return member, nil
+}
+
+// sharedWeaknesses lists the attacking types more than one member is weak to
+// and that fewer members resist, most dangerous first.
+func sharedWeaknesses(team []teamMember) []typeWeakness {
+	var weaknesses []typeWeakness
+	for _, attackType := range allTypes {
+		w := typeWeakness{Type: attackType}
+		for _, member := range team {
+			m := effectiveness(attackType, member.Types)
+			switch {
+			case m > 1:
+				w.Weak++
+			case m < 1:
+				w.Resist++
+			}
+		}
+		if w.Weak > 1 && w.Weak > w.Resist {
+			weaknesses = append(weaknesses, w)
+		}
+	}
+	slices.SortStableFunc(weaknesses, func(a, b typeWeakness) int {
+		return cmp.Compare(b.Weak-b.Resist, a.Weak-a.Resist)
+	})
+	return weaknesses
+}
+
+func teamMoveTypes(team []teamMember) []string {
+	var moveTypes []string
+	for _, member := range team {
+		for _, t := range member.MoveTypes {
+			if !slices.Contains(moveTypes, t) {
+				moveTypes = append(moveTypes, t)
+			}
+		}
+	}
+	slices.Sort(moveTypes)
+	return moveTypes
+}
+
+// coverage splits the defending types into those at least one of moveTypes
+// hits super effectively and those none do.
+func coverage(moveTypes []string, defenders []PokemonType) ([]string, []string) {
+	var covered, gaps []string
+	for _, defender := range defenders {
+		if slices.ContainsFunc(moveTypes, func(t string) bool {
+			return effectiveness(t, []PokemonType{defender}) > 1
+		}) {
+			covered = append(covered, defender.Name)
+		} else {
+			gaps = append(gaps, defender.Name)
+		}
+	}
+	return covered, gaps
+}
+
+// suggestMembers picks the candidates that hit the most coverage gaps super
+// effectively and resist the most shared weaknesses, explaining why.
+func suggestMembers(candidates []teamMember, gaps []string, weaknesses []typeWeakness, defenders []PokemonType) []string {
+	type suggestion struct {
+		text  string
+		score int
+	}
+	var suggestions []suggestion
+	for _, c := range candidates {
+		var gapDefenders []PokemonType
+		for _, d := range defenders {
+			if slices.Contains(gaps, d.Name) {
+				gapDefenders = append(gapDefenders, d)
+			}
+		}
+		covers, _ := coverage(c.MoveTypes, gapDefenders)
+		var resists []string
+		for _, w := range weaknesses {
+			if effectiveness(w.Type, c.Types) < 1 {
+				resists = append(resists, w.Type)
+			}
+		}
+		if len(covers)+len(resists) == 0 {
+			continue
+		}
+		var reasons []string
+		if len(covers) > 0 {
+			reasons = append(reasons, "hits "+strings.Join(covers, ", "))
+		}
+		if len(resists) > 0 {
+			reasons = append(reasons, "resists "+strings.Join(resists, ", "))
+		}
+		text := fmt.Sprintf("%s (ID %d) %s", c.Caught.displayName(), c.Caught.ID, strings.Join(reasons, " and "))
+		suggestions = append(suggestions, suggestion{text, len(covers) + len(resists)})
+	}
+	slices.SortStableFunc(suggestions, func(a, b suggestion) int { return cmp.Compare(b.score, a.score) })
+	var texts []string
+	for _, s := range suggestions[:min(len(suggestions), maxSuggestions)] {
+		texts = append(texts, s.text)
+	}
+	return texts
+}
+
+// printTeamStats shows each member's base stats and the team average.
+func printTeamStats(w io.Writer, team []teamMember) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "\t")
+	for _, column := range statColumns {
+		fmt.Fprintf(tw, "%s\t", column[1])
+	}
+	fmt.Fprintln(tw, "BST\t")
+	totals := make([]int, len(statColumns)+1)
+	for _, member := range team {
+		fmt.Fprintf(tw, "%s\t", member.Caught.displayName())
+		for i, column := range statColumns {
+			stat := baseStat(member.Caught.Pokemon, column[0])
+			totals[i] += stat
+			fmt.Fprintf(tw, "%d\t", stat)
+		}
+		bst := baseStatTotal(member.Caught.Pokemon)
+		totals[len(statColumns)] += bst
+		fmt.Fprintf(tw, "%d\t\n", bst)
+	}
+	fmt.Fprint(tw, "average\t")
+	for _, total := range totals {
+		fmt.Fprintf(tw, "%d\t", total/len(team))
+	}
+	fmt.Fprintln(tw)
+	tw.Flush()
+}
+
+func baseStat(p *Pokemon, name string) int {
+	for _, stat := range p.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat
+		}
+	}
+	return 0
+}
+
+func listOrNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+const rockType = `{"name": "rock", "damage_relations": {
+	"double_damage_from": [{"name": "water"}, {"name": "grass"}, {"name": "fighting"}, {"name": "ground"}, {"name": "steel"}],
+	"half_damage_from": [{"name": "normal"}, {"name": "fire"}, {"name": "poison"}, {"name": "flying"}],
+	"no_damage_from": []
+}}`
+
+func TestTeamAnalysis(t *testing.T) {
+	charizard := teamMember{
+		Caught:    &CaughtPokemon{Pokemon: &Pokemon{Name: "charizard"}, ID: 1},
+		Types:     decodeTypes(t, fireType, flyingType),
+		MoveTypes: []string{"fire", "flying"},
+	}
+	moltres := teamMember{
+		Caught:    &CaughtPokemon{Pokemon: &Pokemon{Name: "moltres"}, ID: 2},
+		Types:     decodeTypes(t, fireType, flyingType),
+		MoveTypes: []string{"fire"},
+	}
+	team := []teamMember{charizard, moltres}
+
+	var weak []string
+	for _, w := range sharedWeaknesses(team) {
+		weak = append(weak, w.Type)
+		if w.Weak != 2 || w.Resist != 0 {
+			t.Errorf("%s: expected 2 weak and 0 resist, got %d and %d", w.Type, w.Weak, w.Resist)
+		}
+	}
+	if !slices.Equal(weak, []string{"water", "electric", "rock"}) {
+		t.Errorf("unexpected shared weaknesses %v", weak)
+	}
+
+	defenders := decodeTypes(t, fireType, flyingType, rockType)
+	covered, gaps := coverage(teamMoveTypes(team), defenders)
+	if len(covered) != 0 || !slices.Equal(gaps, []string{"fire", "flying", "rock"}) {
+		t.Errorf("unexpected coverage %v with gaps %v", covered, gaps)
+	}
+
+	geodude := teamMember{
+		Caught:    &CaughtPokemon{Pokemon: &Pokemon{Name: "geodude"}, ID: 3},
+		Types:     decodeTypes(t, rockType),
+		MoveTypes: []string{"rock", "normal"},
+	}
+	pidgey := teamMember{
+		Caught:    &CaughtPokemon{Pokemon: &Pokemon{Name: "pidgey"}, ID: 4},
+		Types:     decodeTypes(t, flyingType),
+		MoveTypes: []string{"normal"},
+	}
+	suggestions := suggestMembers([]teamMember{pidgey, geodude}, gaps, sharedWeaknesses(team), defenders)
+	expected := []string{"geodude (ID 3) hits fire, flying"}
+	if !slices.Equal(suggestions, expected) {
+		t.Errorf("got suggestions %v, expected %v", suggestions, expected)
+	}
+}
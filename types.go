@@ -0,0 +1,196 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type PokemonType struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+}
+
+func getTypes(names []string) ([]PokemonType, error) {
+	var urls []string
+	for _, name := range names {
+		urls = append(urls, pokeAPI+"type/"+name)
+	}
+	return getAllJSON[PokemonType](urls)
+}
+
+// defenseMultipliers returns how much damage each attacking type does to a
+// Pokemon of the given types. Types that do normal damage are left out.
+func defenseMultipliers(types []PokemonType) map[string]float64 {
+	multipliers := make(map[string]float64)
+	scale := func(attackers []NamedResource, by float64) {
+		for _, attacker := range attackers {
+			if _, ok := multipliers[attacker.Name]; !ok {
+				multipliers[attacker.Name] = 1
+			}
+			multipliers[attacker.Name] *= by
+		}
+	}
+	for _, t := range types {
+		scale(t.DamageRelations.DoubleDamageFrom, 2)
+		scale(t.DamageRelations.HalfDamageFrom, 0.5)
+		scale(t.DamageRelations.NoDamageFrom, 0)
+	}
+	maps.DeleteFunc(multipliers, func(_ string, m float64) bool { return m == 1 })
+	return multipliers
+}
+
+// effectiveness is the multiplier for an attack of attackType hitting a
+// Pokemon of the defending types.
+func effectiveness(attackType string, defenders []PokemonType) float64 {
+	m, ok := defenseMultipliers(defenders)[attackType]
+	if !ok {
+		return 1
+	}
+	return m
+}
+
+func commandTypes(config *Config) error {
+	if len(config.Args) == 0 || len(config.Args) > 2 {
+		fmt.Println("Which types? Usage: types <type> [type2]")
+		return nil
+	}
+	if len(config.Args) == 2 && config.Args[0] == config.Args[1] {
+		fmt.Println("Pick two different types, or just one")
+		return nil
+	}
+	types, err := getTypes(config.Args)
+	if isNotFound(err) {
+		fmt.Println("Unknown type. Types are things like fire, water or dragon")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("Defending as", strings.Join(config.Args, "/")+":")
+	printMultipliers(defenseMultipliers(types))
+	for _, t := range types {
+		fmt.Printf("Attacking with %s:\n", t.Name)
+		printTypeList("Super effective (2x)", t.DamageRelations.DoubleDamageTo)
+		printTypeList("Not very effective (0.5x)", t.DamageRelations.HalfDamageTo)
+		printTypeList("No effect (0x)", t.DamageRelations.NoDamageTo)
+	}
+	return nil
+}
+
+// printMultipliers lists attacking types grouped by multiplier, strongest
+// first.
+func printMultipliers(multipliers map[string]float64) {
+	byMultiplier := make(map[float64][]string)
+	for name, m := range multipliers {
+		byMultiplier[m] = append(byMultiplier[m], name)
+	}
+	if len(byMultiplier) == 0 {
+		fmt.Println("   Takes normal damage from everything")
+		return
+	}
+	for _, m := range slices.SortedFunc(maps.Keys(byMultiplier), func(a, b float64) int { return cmp.Compare(b, a) }) {
+		label := "Weak to"
+		switch {
+		case m == 0:
+			label = "Immune to"
+		case m < 1:
+			label = "Resists"
+		}
+		fmt.Printf("   %-10s %-5s %s\n", label, formatMultiplier(m), strings.Join(slices.Sorted(slices.Values(byMultiplier[m])), ", "))
+	}
+}
+
+func printTypeList(label string, types []NamedResource) {
+	if len(types) == 0 {
+		return
+	}
+	var names []string
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	fmt.Printf("   %s: %s\n", label, strings.Join(names, ", "))
+}
+
+func formatMultiplier(m float64) string {
+	return fmt.Sprintf("%gx", m)
+}
+
+// resolvePokemon finds a Pokemon by ID, nickname or name among the caught
+// ones, falling back to the API for anything not caught.
+func resolvePokemon(idOrName string) (*Pokemon, string, error) {
+	found := findCaught(idOrName)
+	if len(found) > 0 {
+		return found[0].Pokemon, found[0].displayName(), nil
+	}
+	var pokemon Pokemon
+	err := getJSON(pokeAPI+"pokemon/"+idOrName, &pokemon)
+	if err != nil {
+		return nil, "", err
+	}
+	return &pokemon, pokemon.Name, nil
+}
+
+func commandMatchup(config *Config) error {
+	if len(config.Args) != 2 {
+		fmt.Println("Which Pokemon? Usage: matchup <attacker> <defender>")
+		return nil
+	}
+	var pokemon [2]*Pokemon
+	var labels [2]string
+	var types [2][]PokemonType
+	for i, arg := range config.Args {
+		var err error
+		pokemon[i], labels[i], err = resolvePokemon(arg)
+		if isNotFound(err) {
+			fmt.Println(arg, "is not a pokemon or correct id")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		types[i], err = getTypes(pokemonTypes(*pokemon[i]))
+		if err != nil {
+			return err
+		}
+	}
+	printMatchup(labels[0], pokemon[0], labels[1], types[1])
+	printMatchup(labels[1], pokemon[1], labels[0], types[0])
+	return nil
+}
+
+// printMatchup shows how attacks of each of the attacker's types fare against
+// the defender.
+func printMatchup(attacker string, p *Pokemon, defender string, defenderTypes []PokemonType) {
+	var names []string
+	for _, t := range defenderTypes {
+		names = append(names, t.Name)
+	}
+	fmt.Printf("%s attacking %s (%s):\n", attacker, defender, strings.Join(names, "/"))
+	for _, attackType := range pokemonTypes(*p) {
+		m := effectiveness(attackType, defenderTypes)
+		fmt.Printf("   %-10s %s%s\n", attackType, formatMultiplier(m), effectivenessNote(m))
+	}
+}
+
+func effectivenessNote(m float64) string {
+	switch {
+	case m == 0:
+		return " - no effect"
+	case m > 1:
+		return " - super effective"
+	case m < 1:
+		return " - not very effective"
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const fireType = `{"name": "fire", "damage_relations": {
+	"double_damage_from": [{"name": "ground"}, {"name": "rock"}, {"name": "water"}],
+	"half_damage_from": [{"name": "bug"}, {"name": "steel"}, {"name": "fire"}, {"name": "grass"}, {"name": "ice"}, {"name": "fairy"}],
+	"no_damage_from": [],
+	"double_damage_to": [{"name": "bug"}, {"name": "steel"}, {"name": "grass"}, {"name": "ice"}],
+	"half_damage_to": [{"name": "rock"}, {"name": "fire"}, {"name": "water"}, {"name": "dragon"}],
+	"no_damage_to": []
+}}`
+
+const flyingType = `{"name": "flying", "damage_relations": {
+	"double_damage_from": [{"name": "rock"}, {"name": "electric"}, {"name": "ice"}],
+	"half_damage_from": [{"name": "fighting"}, {"name": "bug"}, {"name": "grass"}],
+	"no_damage_from": [{"name": "ground"}],
+	"double_damage_to": [{"name": "fighting"}, {"name": "bug"}, {"name": "grass"}],
+	"half_damage_to": [{"name": "rock"}, {"name": "steel"}, {"name": "electric"}],
+	"no_damage_to": []
+}}`
+
+func decodeTypes(t *testing.T, fixtures ...string) []PokemonType {
+	t.Helper()
+	var types []PokemonType
+	for _, fixture := range fixtures {
+		var pokemonType PokemonType
+		err := decodeJSON(strings.NewReader(fixture), &pokemonType)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		types = append(types, pokemonType)
+	}
+	return types
+}
+
+func TestDefenseMultipliers(t *testing.T) {
+	charizard := decodeTypes(t, fireType, flyingType)
+	cases := map[string]float64{
+		"rock":     4,
+		"water":    2,
+		"electric": 2,
+		"ice":      1,
+		"fire":     0.5,
+		"fighting": 0.5,
+		"grass":    0.25,
+		"bug":      0.25,
+		"ground":   0,
+		"normal":   1,
+	}
+	for attackType, expected := range cases {
+		if actual := effectiveness(attackType, charizard); actual != expected {
+			t.Errorf("%s against fire/flying: got %v, expected %v", attackType, actual, expected)
+		}
+	}
+	if _, ok := defenseMultipliers(charizard)["ice"]; ok {
+		t.Errorf("expected neutral types to be left out")
+	}
+	if actual := effectiveness("ground", decodeTypes(t, fireType)); actual != 2 {
+		t.Errorf("ground against fire: got %v, expected 2", actual)
+	}
+}
+
+func TestTypesRejectsRepeat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request for %s", r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	pokeAPI = srv.URL + "/"
+
+	err := commandTypes(&Config{Args: []string{"fire", "fire"}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	// sleepAccuracy and paralyzeAccuracy are the chances that sleep powder
+	// and thunder wave land.
+	sleepAccuracy    = 75
+	paralyzeAccuracy = 90
+	// pokeBallBonus is the catch rate multiplier of a plain Poke Ball.
+	pokeBallBonus = 1
+)
+
+// statusBonus is how much each status condition multiplies the catch rate.
+var statusBonus = map[string]float64{
+	"sleep":     2.5,
+	"paralysis": 1.5,
+}
+
+// wildPokemon is a wild Pokemon the player is trying to catch. It keeps its
+// HP and status between commands until the next explore.
+type wildPokemon struct {
+	Pokemon Pokemon
+	Level   int
+	IVs     map[string]int
+	Nature  string
+	HP      int
+	MaxHP   int
+	Status  string
+}
+
+// wildState returns the wild pokemon the player is facing, meeting it at full
+// HP if they haven't yet. Pokemon that aren't in the explored area aren't
+// remembered.
+func wildState(config *Config, pokemon Pokemon) *wildPokemon {
+	if wild, ok := config.Wild[pokemon.Name]; ok {
+		return wild
+	}
+	ivs := make(map[string]int)
+	for _, stat := range pokemon.Stats {
+		ivs[stat.Stat.Name] = rand.Intn(maxIV + 1)
+	}
+	wild := &wildPokemon{
+		Pokemon: pokemon,
+		Level:   catchLevel(config, pokemon.Name, rand.Intn),
+		IVs:     ivs,
+		Nature:  natures[rand.Intn(len(natures))].Name,
+	}
+	wild.MaxHP = battleStats(pokemon, wild.Level, ivs, nil, wild.Nature)["hp"]
+	wild.HP = wild.MaxHP
+	if inArea(config, pokemon.Name) && config.Wild != nil {
+		config.Wild[pokemon.Name] = wild
+	}
+	return wild
+}
+
+func inArea(config *Config, name string) bool {
+	return slices.ContainsFunc(config.Encounters, func(e encounterSummary) bool { return e.Name == name })
+}
+
+func (w *wildPokemon) describe() string {
+	status := ""
+	if w.Status != "" {
+		status = ", " + w.Status
+	}
+	return fmt.Sprintf("Wild %s Lv %d: %d/%d HP%s", w.Pokemon.Name, w.Level, w.HP, w.MaxHP, status)
+}
+
+func commandWeaken(config *Config) error {
+	if len(config.Args) == 0 {
+		fmt.Println("Which Pokemon? Usage: weaken <name> [attack|sleep|paralyze]")
+		return nil
+	}
+	name, action := config.Args[0], "attack"
+	if len(config.Args) > 1 {
+		action = config.Args[1]
+	}
+	if !inArea(config, name) {
+		fmt.Printf("There is no %s around. Explore to find wild Pokemon\n", name)
+		return nil
+	}
+	var pokemon Pokemon
+	err := getJSON(pokeAPI+"pokemon/"+name, &pokemon)
+	if err != nil {
+		return err
+	}
+	wild := wildState(config, pokemon)
+	if wild.HP == 0 {
+		fmt.Printf("The wild %s has fainted. Explore again to find another\n", name)
+		return nil
+	}
+	switch action {
+	case "attack":
+		err = weakenAttack(config, wild)
+		if err != nil {
+			return err
+		}
+	case "sleep":
+		inflictStatus(wild, "sleep", sleepAccuracy, rand.Intn(100))
+	case "paralyze":
+		inflictStatus(wild, "paralysis", paralyzeAccuracy, rand.Intn(100))
+	default:
+		return fmt.Errorf("can't %s, use attack, sleep or paralyze", action)
+	}
+	if wild.HP == 0 {
+		fmt.Printf("The wild %s fainted! It can't be caught now\n", name)
+		return nil
+	}
+	fmt.Println(wild.describe())
+	return nil
+}
+
+// weakenAttack has the lead of the player's party hit the wild Pokemon with
+// its strongest move.
+func weakenAttack(config *Config, wild *wildPokemon) error {
+	if len(pokedex.party) == 0 {
+		fmt.Println("You have no Pokemon in your party to attack with")
+		return nil
+	}
+	lead := pokedex.capturedPokemon[pokedex.party[0]]
+	attacker, err := caughtBattler(lead, config.GameGroup)
+	if err != nil {
+		return err
+	}
+	moves := levelUpMoves(wild.Pokemon, wild.Level, config.GameGroup)
+	defender, err := newBattler("wild "+wild.Pokemon.Name, wild.Pokemon, wild.Level, wild.IVs, nil, wild.Nature, moves)
+	if err != nil {
+		return err
+	}
+	defender.HP = wild.HP
+	b := &battle{out: os.Stdout, rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	b.attack(attacker, defender, bestMove(attacker, defender))
+	wild.HP = defender.HP
+	return nil
+}
+
+// inflictStatus tries to give the wild Pokemon a status condition. roll is a
+// number from 0 to 99 that has to be under accuracy for it to land.
+func inflictStatus(wild *wildPokemon, status string, accuracy, roll int) {
+	if wild.Status != "" {
+		fmt.Printf("The wild %s already has %s\n", wild.Pokemon.Name, wild.Status)
+		return
+	}
+	if roll >= accuracy {
+		fmt.Println("It missed!")
+		return
+	}
+	wild.Status = status
+	fmt.Printf("The wild %s was inflicted with %s!\n", wild.Pokemon.Name, status)
+}
+
+// catchRate is the modified catch rate from the main series games. It is 255
+// or more when the catch is guaranteed.
+func catchRate(wild *wildPokemon, captureRate int) float64 {
+	rate := float64(3*wild.MaxHP-2*wild.HP) * float64(captureRate) * pokeBallBonus / float64(3*wild.MaxHP)
+	if bonus, ok := statusBonus[wild.Status]; ok {
+		rate *= bonus
+	}
+	return rate
+}
+
+// shakeChance is the chance out of 65536 that the ball shakes, from the
+// modified catch rate. The Pokemon is caught after four shakes.
+func shakeChance(rate float64) int {
+	if rate >= 255 {
+		return 65536
+	}
+	return int(1048560 / math.Sqrt(math.Sqrt(16711680/rate)))
+}
+
+// catchAttempt throws a ball at the wild Pokemon and returns how many times it
+// shook. It is caught if it shook four times.
+func catchAttempt(wild *wildPokemon, captureRate int) int {
+	chance := shakeChance(catchRate(wild, captureRate))
+	shakes := 0
+	for shakes < 4 && rand.Intn(65536) < chance {
+		shakes++
+	}
+	return shakes
+}
@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestCatchRate(t *testing.T) {
+	cases := []struct {
+		hp       int
+		status   string
+		rate     int
+		expected float64
+	}{
+		{100, "", 45, 15},
+		{1, "", 45, 44.7},
+		{1, "sleep", 45, 111.75},
+		{50, "paralysis", 190, 190},
+		{1, "sleep", 255, 633.25},
+	}
+	for _, c := range cases {
+		wild := &wildPokemon{HP: c.hp, MaxHP: 100, Status: c.status}
+		actual := catchRate(wild, c.rate)
+		if diff := actual - c.expected; diff > 0.001 || diff < -0.001 {
+			t.Errorf("%d HP %q rate %d: got %v, expected %v", c.hp, c.status, c.rate, actual, c.expected)
+		}
+	}
+}
+
+func TestShakeChance(t *testing.T) {
+	if chance := shakeChance(255); chance != 65536 {
+		t.Errorf("guaranteed catch: got %d, expected 65536", chance)
+	}
+	weak, strong := shakeChance(3), shakeChance(111.75)
+	if weak >= strong || strong >= 65536 {
+		t.Errorf("expected a higher catch rate to shake more: got %d and %d", weak, strong)
+	}
+	wild := &wildPokemon{HP: 1, MaxHP: 100, Status: "sleep"}
+	if shakes := catchAttempt(wild, 255); shakes != 4 {
+		t.Errorf("expected a guaranteed catch, got %d shakes", shakes)
+	}
+}
+
+func TestInflictStatus(t *testing.T) {
+	wild := &wildPokemon{Pokemon: Pokemon{Name: "pidgey"}, HP: 20, MaxHP: 20}
+	inflictStatus(wild, "sleep", sleepAccuracy, sleepAccuracy)
+	if wild.Status != "" {
+		t.Errorf("expected the move to miss, got %q", wild.Status)
+	}
+	inflictStatus(wild, "sleep", sleepAccuracy, 0)
+	if wild.Status != "sleep" {
+		t.Errorf("expected sleep, got %q", wild.Status)
+	}
+	inflictStatus(wild, "paralysis", paralyzeAccuracy, 0)
+	if wild.Status != "sleep" {
+		t.Errorf("expected a second status to fail, got %q", wild.Status)
+	}
+}